@@ -221,6 +221,33 @@ func (c *DumpCommand) dumpSockAddr(sa sockaddr.SockAddr) {
 		}
 	}
 
+	if sa.Type() == sockaddr.TypeHostname {
+		h := *sockaddr.ToHostname(sa)
+		for _, attr := range sockaddr.HostnameAttrs() {
+			output = outFmt(output, attr, sockaddr.HostnameAttr(h, attr))
+		}
+	}
+
+	if sa.Type() == sockaddr.TypeIPPortRange {
+		r := *sockaddr.ToIPPortRange(sa)
+		for _, attr := range sockaddr.IPAttrs() {
+			if attr == "port" {
+				continue
+			}
+			output = outFmt(output, attr, sockaddr.IPAddrAttr(r.Addr, attr))
+		}
+		for _, attr := range sockaddr.IPPortRangeAttrs() {
+			output = outFmt(output, attr, sockaddr.IPPortRangeAttr(r, attr))
+		}
+	}
+
+	if sa.Type() == sockaddr.TypeIPRange {
+		r := *sockaddr.ToIPRange(sa)
+		for _, attr := range sockaddr.IPRangeAttrs() {
+			output = outFmt(output, attr, sockaddr.IPRangeAttr(r, attr))
+		}
+	}
+
 	// Developer-focused arguments
 	{
 		arg1, arg2 := sa.DialPacketArgs()
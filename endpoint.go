@@ -0,0 +1,216 @@
+package sockaddr
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Endpoint pairs a SockAddr with a transport, i.e. the network type passed to
+// net.Dial() and net.Listen().  Endpoints are written as URLs (e.g.
+// `tcp://[::1]:8080`, `udp4://0.0.0.0:53` or `unix:///run/app.sock`).
+type Endpoint struct {
+	// Transport is one of `tcp`, `tcp4`, `tcp6`, `udp`, `udp4`, `udp6`,
+	// `unix`, `unixgram` or `unixpacket`.
+	Transport string
+
+	// SockAddr is an IPv4Addr, IPv6Addr or Hostname for the IP transports and
+	// a UnixSock for the UNIX transports.
+	SockAddr SockAddr
+}
+
+// endpointTransports maps each supported transport to the SockAddr types it
+// accepts.
+var endpointTransports = map[string]SockAddrType{
+	"tcp":        TypeIP | TypeHostname,
+	"tcp4":       TypeIPv4 | TypeHostname,
+	"tcp6":       TypeIPv6 | TypeHostname,
+	"udp":        TypeIP | TypeHostname,
+	"udp4":       TypeIPv4 | TypeHostname,
+	"udp6":       TypeIPv6 | TypeHostname,
+	"unix":       TypeUnix,
+	"unixgram":   TypeUnix,
+	"unixpacket": TypeUnix,
+}
+
+// NewEndpoint creates an Endpoint from a transport and a SockAddr, returning
+// an error if the transport is unknown or does not support the SockAddr's
+// type.
+func NewEndpoint(transport string, sa SockAddr) (Endpoint, error) {
+	transport = strings.ToLower(transport)
+	types, found := endpointTransports[transport]
+	if !found {
+		return Endpoint{}, fmt.Errorf("Unknown endpoint transport %+q", transport)
+	}
+
+	if sa == nil || sa.Type()&types == 0 {
+		return Endpoint{}, fmt.Errorf("Transport %+q does not support %v", transport, sa)
+	}
+
+	if ipAddr, ok := sa.(IPAddr); ok && !ipAddr.Equal(ipAddr.Host()) {
+		return Endpoint{}, fmt.Errorf("Unable to create an endpoint for %s: not a host address", sa)
+	}
+
+	return Endpoint{
+		Transport: transport,
+		SockAddr:  sa,
+	}, nil
+}
+
+// ParseEndpoint creates an Endpoint from a URL-style string.  The address of
+// the IP transports must be an IP address or hostname with a port, IPv6
+// addresses must be enclosed in brackets (e.g. `tcp://[::1]:8080`).  The
+// address of the UNIX transports is the path of the socket (e.g.
+// `unix:///run/app.sock`, `unixgram://./app.sock` or `unix://@app`).
+func ParseEndpoint(s string) (Endpoint, error) {
+	i := strings.Index(s, "://")
+	if i == -1 {
+		return Endpoint{}, fmt.Errorf("Unable to parse %+q as an endpoint: missing transport", s)
+	}
+
+	transport, addr := strings.ToLower(s[:i]), s[i+len("://"):]
+	types, found := endpointTransports[transport]
+	if !found {
+		return Endpoint{}, fmt.Errorf("Unable to parse %+q as an endpoint: unknown transport %+q", s, transport)
+	}
+
+	if addr == "" {
+		return Endpoint{}, fmt.Errorf("Unable to parse %+q as an endpoint: missing address", s)
+	}
+
+	var sa SockAddr
+	var err error
+	if types == TypeUnix {
+		sa, err = NewUnixSock(addr)
+	} else {
+		// IPv4-mapped addresses are valid on the IPv6-only transports (e.g.
+		// `tcp6://[::ffff:192.0.2.1]:80`) and must not become an IPv4Addr.
+		sa, err = ParseSockAddr(addr, ParseOptions{
+			KeepMapped:    types&TypeIPv4 == 0,
+			AllowHostname: true,
+			Strict:        true,
+			Families:      types,
+			Port:          PortRequired,
+		})
+	}
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("Unable to parse %+q as an endpoint: %w", s, err)
+	}
+
+	return Endpoint{
+		Transport: transport,
+		SockAddr:  sa,
+	}, nil
+}
+
+// MustEndpoint is a helper method that must return an Endpoint or panic on
+// invalid input.
+func MustEndpoint(s string) Endpoint {
+	e, err := ParseEndpoint(s)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to create an Endpoint from %+q: %v", s, err))
+	}
+	return e
+}
+
+// DialArgs returns the arguments required to be passed to net.Dial() to
+// connect to the Endpoint.  The network is the Endpoint's transport.  If the
+// SockAddr can not be dialed (e.g. it has no port), dialArgs is empty.
+func (e Endpoint) DialArgs() (network, dialArgs string) {
+	if e.SockAddr == nil {
+		return e.Transport, ""
+	}
+
+	switch e.Transport {
+	case "tcp", "tcp4", "tcp6", "unix":
+		_, dialArgs = e.SockAddr.DialStreamArgs()
+	case "udp", "udp4", "udp6", "unixgram":
+		_, dialArgs = e.SockAddr.DialPacketArgs()
+	case "unixpacket":
+		if us, ok := e.SockAddr.(UnixSock); ok {
+			_, dialArgs = us.DialSeqPacketArgs()
+		}
+	}
+
+	return e.Transport, dialArgs
+}
+
+// ListenArgs returns the arguments required to be passed to net.Listen() or
+// net.ListenPacket() to listen on the Endpoint.  The network is the
+// Endpoint's transport.
+func (e Endpoint) ListenArgs() (network, listenArgs string) {
+	if e.SockAddr == nil {
+		return e.Transport, ""
+	}
+
+	switch e.Transport {
+	case "tcp", "tcp4", "tcp6", "unix":
+		_, listenArgs = e.SockAddr.ListenStreamArgs()
+	case "udp", "udp4", "udp6", "unixgram":
+		_, listenArgs = e.SockAddr.ListenPacketArgs()
+	case "unixpacket":
+		if us, ok := e.SockAddr.(UnixSock); ok {
+			_, listenArgs = us.ListenSeqPacketArgs()
+		}
+	}
+
+	return e.Transport, listenArgs
+}
+
+// Equal returns true if e2 has the same transport and an equal SockAddr.
+func (e Endpoint) Equal(e2 Endpoint) bool {
+	if e.Transport != e2.Transport {
+		return false
+	}
+
+	if e.SockAddr == nil || e2.SockAddr == nil {
+		return e.SockAddr == nil && e2.SockAddr == nil
+	}
+
+	return e.SockAddr.Equal(e2.SockAddr)
+}
+
+// String returns the Endpoint as a URL (e.g. `tcp://[::1]:8080` or
+// `unix:///run/app.sock`).
+func (e Endpoint) String() string {
+	if e.SockAddr == nil {
+		return e.Transport + "://"
+	}
+
+	// The port is always included, even if it is zero, so the URL can be
+	// parsed again.
+	switch v := e.SockAddr.(type) {
+	case UnixSock:
+		return e.Transport + "://" + v.path
+	case IPv4Addr:
+		return fmt.Sprintf("%s://%s:%d", e.Transport, v.NetIP(), v.Port)
+	case IPv6Addr:
+		return fmt.Sprintf("%s://[%s]:%d", e.Transport, v.zonedIPString(), v.Port)
+	case Hostname:
+		return fmt.Sprintf("%s://%s", e.Transport, net.JoinHostPort(v.Host, strconv.Itoa(int(v.Port))))
+	default:
+		return e.Transport + "://" + e.SockAddr.String()
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the same value as
+// String().  Endpoints are encoded as JSON strings.
+func (e Endpoint) MarshalText() ([]byte, error) {
+	if e.SockAddr == nil {
+		return nil, fmt.Errorf("Unable to marshal an Endpoint without a SockAddr")
+	}
+
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseEndpoint().
+func (e *Endpoint) UnmarshalText(text []byte) error {
+	v, err := ParseEndpoint(string(text))
+	if err != nil {
+		return err
+	}
+
+	*e = v
+	return nil
+}
@@ -0,0 +1,216 @@
+package sockaddr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		input       string
+		str         string
+		typ         sockaddr.SockAddrType
+		dialNetwork string
+		dialArgs    string
+		listenArgs  string
+		fail        bool
+	}{
+		{
+			input:       "tcp://[::1]:8080",
+			str:         "tcp://[::1]:8080",
+			typ:         sockaddr.TypeIPv6,
+			dialNetwork: "tcp",
+			dialArgs:    "[::1]:8080",
+			listenArgs:  "[::1]:8080",
+		},
+		{
+			input:       "udp4://0.0.0.0:53",
+			str:         "udp4://0.0.0.0:53",
+			typ:         sockaddr.TypeIPv4,
+			dialNetwork: "udp4",
+			dialArgs:    "0.0.0.0:53",
+			listenArgs:  "0.0.0.0:53",
+		},
+		{
+			input:       "TCP6://[fe80::1%eth0]:22",
+			str:         "tcp6://[fe80::1%eth0]:22",
+			typ:         sockaddr.TypeIPv6,
+			dialNetwork: "tcp6",
+			dialArgs:    "[fe80::1%eth0]:22",
+			listenArgs:  "[fe80::1%eth0]:22",
+		},
+		{
+			input:       "tcp6://[::ffff:192.0.2.1]:80",
+			str:         "tcp6://[::ffff:192.0.2.1]:80",
+			typ:         sockaddr.TypeIPv6,
+			dialNetwork: "tcp6",
+			dialArgs:    "[::ffff:192.0.2.1]:80",
+			listenArgs:  "[::ffff:192.0.2.1]:80",
+		},
+		{
+			input:       "tcp://[::ffff:192.0.2.1]:80",
+			str:         "tcp://192.0.2.1:80",
+			typ:         sockaddr.TypeIPv4,
+			dialNetwork: "tcp",
+			dialArgs:    "192.0.2.1:80",
+			listenArgs:  "192.0.2.1:80",
+		},
+		{
+			input:       "tcp://127.0.0.1:0",
+			str:         "tcp://127.0.0.1:0",
+			typ:         sockaddr.TypeIPv4,
+			dialNetwork: "tcp",
+			listenArgs:  "127.0.0.1:0",
+		},
+		{
+			input:       "udp://consul.service:8600",
+			str:         "udp://consul.service:8600",
+			typ:         sockaddr.TypeHostname,
+			dialNetwork: "udp",
+			dialArgs:    "consul.service:8600",
+			listenArgs:  "consul.service:8600",
+		},
+		{
+			input:       "unix:///run/app.sock",
+			str:         "unix:///run/app.sock",
+			typ:         sockaddr.TypeUnix,
+			dialNetwork: "unix",
+			dialArgs:    "/run/app.sock",
+			listenArgs:  "/run/app.sock",
+		},
+		{
+			input:       "unixgram://./app.sock",
+			str:         "unixgram://./app.sock",
+			typ:         sockaddr.TypeUnix,
+			dialNetwork: "unixgram",
+			dialArgs:    "./app.sock",
+			listenArgs:  "./app.sock",
+		},
+		{
+			input:       "unixpacket://@app",
+			str:         "unixpacket://@app",
+			typ:         sockaddr.TypeUnix,
+			dialNetwork: "unixpacket",
+			dialArgs:    "@app",
+			listenArgs:  "@app",
+		},
+		{input: "[::1]:8080", fail: true},
+		{input: "http://[::1]:8080", fail: true},
+		{input: "tcp4://[::1]:8080", fail: true},
+		{input: "udp6://10.0.0.1:53", fail: true},
+		{input: "tcp://10.0.0.1", fail: true},
+		{input: "tcp://10.0.0.0/8:80", fail: true},
+		{input: "tcp://::1:8080", fail: true},
+		{input: "tcp:///run/app.sock", fail: true},
+		{input: "unix://", fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			e, err := sockaddr.ParseEndpoint(test.input)
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected %q to fail, received %v", test.input, e)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if e.String() != test.str {
+				t.Fatalf("expected %q, received %q", test.str, e.String())
+			}
+
+			if e.SockAddr.Type() != test.typ {
+				t.Fatalf("expected a %s, received %s", test.typ, e.SockAddr.Type())
+			}
+
+			network, dialArgs := e.DialArgs()
+			if network != test.dialNetwork || dialArgs != test.dialArgs {
+				t.Fatalf("expected DialArgs() %q %q, received %q %q", test.dialNetwork, test.dialArgs, network, dialArgs)
+			}
+
+			_, listenArgs := e.ListenArgs()
+			if listenArgs != test.listenArgs {
+				t.Fatalf("expected ListenArgs() %q, received %q", test.listenArgs, listenArgs)
+			}
+
+			again, err := sockaddr.ParseEndpoint(e.String())
+			if err != nil || !again.Equal(e) {
+				t.Fatalf("expected %q to round-trip, received %v, %v", e, again, err)
+			}
+		})
+	}
+}
+
+func TestNewEndpoint(t *testing.T) {
+	e, err := sockaddr.NewEndpoint("udp6", sockaddr.MustIPv6Addr("::1"))
+	if err != nil {
+		t.Fatalf("unable to create endpoint: %v", err)
+	}
+	if e.String() != "udp6://[::1]:0" {
+		t.Fatalf("unexpected endpoint %q", e)
+	}
+
+	for _, test := range []struct {
+		transport string
+		sa        sockaddr.SockAddr
+	}{
+		{"sctp", sockaddr.MustIPv4Addr("10.0.0.1:80")},
+		{"tcp4", sockaddr.MustIPv6Addr("[::1]:80")},
+		{"unix", sockaddr.MustIPv4Addr("10.0.0.1:80")},
+		{"tcp", sockaddr.MustUnixSock("/run/app.sock")},
+		{"tcp", sockaddr.MustIPv4Addr("10.0.0.0/8")},
+		{"tcp", nil},
+	} {
+		if _, err := sockaddr.NewEndpoint(test.transport, test.sa); err == nil {
+			t.Errorf("expected %s %v to fail", test.transport, test.sa)
+		}
+	}
+}
+
+func TestEndpoint_JSON(t *testing.T) {
+	type config struct {
+		Listen  sockaddr.Endpoint
+		Peers   []sockaddr.Endpoint
+		Control *sockaddr.Endpoint `json:",omitempty"`
+	}
+
+	in := config{
+		Listen: sockaddr.MustEndpoint("udp4://0.0.0.0:53"),
+		Peers: []sockaddr.Endpoint{
+			sockaddr.MustEndpoint("tcp://[::1]:8080"),
+			sockaddr.MustEndpoint("unix:///run/app.sock"),
+		},
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unable to marshal: %v", err)
+	}
+
+	const expected = `{"Listen":"udp4://0.0.0.0:53","Peers":["tcp://[::1]:8080","unix:///run/app.sock"]}`
+	if string(data) != expected {
+		t.Fatalf("expected %s, received %s", expected, data)
+	}
+
+	var out config
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unable to unmarshal: %v", err)
+	}
+
+	if !out.Listen.Equal(in.Listen) || len(out.Peers) != 2 || !out.Peers[0].Equal(in.Peers[0]) || !out.Peers[1].Equal(in.Peers[1]) {
+		t.Fatalf("expected %+v, received %+v", in, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Listen":"udp4://[::1]:53"}`), &out); err == nil {
+		t.Fatalf("expected an invalid endpoint to fail")
+	}
+
+	if _, err := json.Marshal(config{}); err == nil {
+		t.Fatalf("expected an empty endpoint to fail to marshal")
+	}
+}
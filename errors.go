@@ -0,0 +1,128 @@
+package sockaddr
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidMask is returned, usually wrapped in a ParseError, when a
+	// netmask or prefix length is malformed or out of range for its address
+	// family.
+	ErrInvalidMask = errors.New("invalid netmask")
+
+	// ErrUnknownSelector is returned when an include, exclude, sort, unique
+	// or interface flag selector is not recognized.
+	ErrUnknownSelector = errors.New("unknown selector")
+
+	// ErrUnknownAttribute is returned when an attribute name is not
+	// supported by a SockAddr or IfAddr.
+	ErrUnknownAttribute = errors.New("unknown attribute")
+
+	// ErrAmbiguousOctal is wrapped by the warnings of ParseIPv4Legacy() for
+	// parts of an address with a leading zero whose octal value differs from
+	// their decimal value (e.g. `012`).
+	ErrAmbiguousOctal = errors.New("ambiguous octal number")
+
+	// ErrNoDefaultRoute is returned when the interface with the default route
+	// can not be determined.
+	ErrNoDefaultRoute = errors.New("no default route found")
+)
+
+// ParseErrorKind describes which part of the input a ParseError refers to.
+type ParseErrorKind int
+
+const (
+	// KindSyntax means the input is not in a recognized form.
+	KindSyntax ParseErrorKind = iota
+
+	// KindFamily means the input belongs to a different or disallowed address
+	// family (e.g. an IPv4 address passed to NewIPv6Addr()).
+	KindFamily
+
+	// KindMask means the netmask or prefix length is invalid.  The Cause of
+	// ParseErrors of this kind wraps ErrInvalidMask.
+	KindMask
+
+	// KindPort means the port is invalid, missing or not allowed.
+	KindPort
+
+	// KindZone means the IPv6 zone is invalid.
+	KindZone
+
+	// KindPath means the UNIX socket path is invalid.
+	KindPath
+)
+
+// String returns a human readable name for the ParseErrorKind.
+func (k ParseErrorKind) String() string {
+	switch k {
+	case KindSyntax:
+		return "syntax"
+	case KindFamily:
+		return "family"
+	case KindMask:
+		return "mask"
+	case KindPort:
+		return "port"
+	case KindZone:
+		return "zone"
+	case KindPath:
+		return "path"
+	default:
+		return fmt.Sprintf("ParseErrorKind(%d)", int(k))
+	}
+}
+
+// ParseError is returned by the constructors and parsers of this package
+// (e.g. NewIPv4Addr(), NewSockAddr() and ParseSockAddr()) when their input
+// can not be parsed.  Use errors.As() to retrieve a ParseError from a wrapped
+// error.
+type ParseError struct {
+	// Input is the string that failed to parse.
+	Input string
+
+	// Kind describes which part of the input is invalid.
+	Kind ParseErrorKind
+
+	// Family is the type of SockAddr the input was parsed as, or TypeUnknown
+	// if the input matched no SockAddr type.
+	Family SockAddrType
+
+	// Cause is the underlying error, if any.
+	Cause error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	var what string
+	switch e.Family {
+	case TypeIPv4:
+		what = "an IPv4 address"
+	case TypeIPv6:
+		what = "an IPv6 address"
+	case TypeIP:
+		what = "an IP address"
+	case TypeUnix:
+		what = "a UNIX socket"
+	case TypeHostname:
+		what = "a hostname"
+	case TypeIPPortRange:
+		what = "an IP port range"
+	case TypeIPRange:
+		what = "an IP address range"
+	default:
+		what = "a socket address"
+	}
+
+	if e.Cause == nil {
+		return fmt.Sprintf("Unable to parse %+q as %s", e.Input, what)
+	}
+
+	return fmt.Sprintf("Unable to parse %+q as %s: %v", e.Input, what, e.Cause)
+}
+
+// Unwrap returns the underlying cause of the ParseError.
+func (e *ParseError) Unwrap() error {
+	return e.Cause
+}
@@ -0,0 +1,191 @@
+package sockaddr_test
+
+import (
+	"errors"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name   string
+		parse  func(string) error
+		input  string
+		kind   sockaddr.ParseErrorKind
+		family sockaddr.SockAddrType
+		mask   bool
+	}{
+		{
+			name:   "ipv4 syntax",
+			parse:  func(s string) error { _, err := sockaddr.NewIPv4Addr(s); return err },
+			input:  "256.0.0.1",
+			kind:   sockaddr.KindSyntax,
+			family: sockaddr.TypeIPv4,
+		},
+		{
+			name:   "ipv4 mask",
+			parse:  func(s string) error { _, err := sockaddr.NewIPv4Addr(s); return err },
+			input:  "10.0.0.0/33",
+			kind:   sockaddr.KindMask,
+			family: sockaddr.TypeIPv4,
+			mask:   true,
+		},
+		{
+			name:   "ipv6 family",
+			parse:  func(s string) error { _, err := sockaddr.NewIPv6Addr(s); return err },
+			input:  "10.0.0.1",
+			kind:   sockaddr.KindFamily,
+			family: sockaddr.TypeIPv6,
+		},
+		{
+			name:   "ipv6 mask",
+			parse:  func(s string) error { _, err := sockaddr.NewIPv6Addr(s); return err },
+			input:  "2001:db8::/129",
+			kind:   sockaddr.KindMask,
+			family: sockaddr.TypeIPv6,
+			mask:   true,
+		},
+		{
+			name:   "ipv6 zone",
+			parse:  func(s string) error { _, err := sockaddr.NewIPv6Addr(s); return err },
+			input:  "fe80::1%",
+			kind:   sockaddr.KindZone,
+			family: sockaddr.TypeIPv6,
+		},
+		{
+			name:   "ipaddr syntax",
+			parse:  func(s string) error { _, err := sockaddr.NewIPAddr(s); return err },
+			input:  "not-an-ip",
+			kind:   sockaddr.KindSyntax,
+			family: sockaddr.TypeIP,
+		},
+		{
+			name:   "ipaddr mask",
+			parse:  func(s string) error { _, err := sockaddr.NewIPAddr(s); return err },
+			input:  "2001:db8::/129",
+			kind:   sockaddr.KindMask,
+			family: sockaddr.TypeIPv6,
+			mask:   true,
+		},
+		{
+			name:   "unix path",
+			parse:  func(s string) error { _, err := sockaddr.NewUnixSock(s); return err },
+			input:  "@",
+			kind:   sockaddr.KindPath,
+			family: sockaddr.TypeUnix,
+		},
+		{
+			name:   "hostname port",
+			parse:  func(s string) error { _, err := sockaddr.NewHostname(s); return err },
+			input:  "example.com:http",
+			kind:   sockaddr.KindPort,
+			family: sockaddr.TypeHostname,
+		},
+		{
+			name:   "sockaddr syntax",
+			parse:  func(s string) error { _, err := sockaddr.NewSockAddr(s); return err },
+			input:  "a",
+			kind:   sockaddr.KindSyntax,
+			family: sockaddr.TypeUnknown,
+		},
+		{
+			name: "strict mask",
+			parse: func(s string) error {
+				_, err := sockaddr.ParseSockAddr(s, sockaddr.ParseOptions{Strict: true})
+				return err
+			},
+			input:  "192.168.3.51/00ffffff",
+			kind:   sockaddr.KindMask,
+			family: sockaddr.TypeIP,
+			mask:   true,
+		},
+		{
+			name: "port required",
+			parse: func(s string) error {
+				_, err := sockaddr.ParseSockAddr(s, sockaddr.ParseOptions{Port: sockaddr.PortRequired})
+				return err
+			},
+			input:  "10.0.0.1",
+			kind:   sockaddr.KindPort,
+			family: sockaddr.TypeIPv4,
+		},
+		{
+			name: "family not allowed",
+			parse: func(s string) error {
+				_, err := sockaddr.ParseSockAddr(s, sockaddr.ParseOptions{Families: sockaddr.TypeIPv4})
+				return err
+			},
+			input:  "/tmp/foo.sock",
+			kind:   sockaddr.KindFamily,
+			family: sockaddr.TypeUnix,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.parse(test.input)
+			if err == nil {
+				t.Fatalf("expected %q to fail", test.input)
+			}
+
+			var pe *sockaddr.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected a ParseError, got %T: %v", err, err)
+			}
+			if pe.Input != test.input {
+				t.Errorf("wrong Input: %q vs %q", pe.Input, test.input)
+			}
+			if pe.Kind != test.kind {
+				t.Errorf("wrong Kind: %s vs %s", pe.Kind, test.kind)
+			}
+			if pe.Family != test.family {
+				t.Errorf("wrong Family: %s vs %s", pe.Family, test.family)
+			}
+			if errors.Is(err, sockaddr.ErrInvalidMask) != test.mask {
+				t.Errorf("expected errors.Is(ErrInvalidMask) to be %t: %v", test.mask, err)
+			}
+		})
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	ifAddrs := sockaddr.IfAddrs{
+		sockaddr.IfAddr{SockAddr: sockaddr.MustIPv4Addr("10.0.0.1/24")},
+	}
+
+	_, err := sockaddr.SortIfBy("address,bogus", ifAddrs)
+	if !errors.Is(err, sockaddr.ErrUnknownSelector) {
+		t.Errorf("SortIfBy: expected ErrUnknownSelector, got %v", err)
+	}
+
+	_, _, err = sockaddr.IfByFlag("up|bogus", ifAddrs)
+	if !errors.Is(err, sockaddr.ErrUnknownSelector) {
+		t.Errorf("IfByFlag: expected ErrUnknownSelector, got %v", err)
+	}
+
+	_, err = sockaddr.UniqueIfAddrsBy("bogus", ifAddrs)
+	if !errors.Is(err, sockaddr.ErrUnknownSelector) {
+		t.Errorf("UniqueIfAddrsBy: expected ErrUnknownSelector, got %v", err)
+	}
+
+	_, _, err = sockaddr.IfByRFC("9999", ifAddrs)
+	if !errors.Is(err, sockaddr.ErrUnknownSelector) {
+		t.Errorf("IfByRFC: expected ErrUnknownSelector, got %v", err)
+	}
+
+	_, err = sockaddr.IfAddrsMath("bogus", "1", ifAddrs)
+	if !errors.Is(err, sockaddr.ErrUnknownSelector) {
+		t.Errorf("IfAddrsMath: expected ErrUnknownSelector, got %v", err)
+	}
+
+	_, err = sockaddr.Attr(ifAddrs[0].SockAddr, "bogus")
+	if !errors.Is(err, sockaddr.ErrUnknownAttribute) {
+		t.Errorf("Attr: expected ErrUnknownAttribute, got %v", err)
+	}
+
+	_, err = sockaddr.IfAddrsMath("mask", "33", ifAddrs)
+	if !errors.Is(err, sockaddr.ErrInvalidMask) {
+		t.Errorf("IfAddrsMath: expected ErrInvalidMask, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package sockaddr
+
+import (
+	"fmt"
+	"net"
+)
+
+// eui64PrefixLen is the prefix length of the networks SLAAC addresses are
+// derived in (RFC 4862).
+const eui64PrefixLen = 64
+
+// EUI64From returns the SLAAC address derived from the modified EUI-64
+// interface identifier of mac within the IPv6Addr's /64 network (RFC 4291,
+// Appendix A).  mac must be an EUI-48 (e.g. `00:11:22:33:44:55`, which yields
+// the interface identifier `211:22ff:fe33:4455`) or EUI-64 address, such as
+// IfAddr.Interface.HardwareAddr.  The zone of the IPv6Addr is preserved.
+func (ipv6 IPv6Addr) EUI64From(mac net.HardwareAddr) (IPv6Addr, error) {
+	ones, err := ipv6.prefixLen()
+	if err != nil {
+		return IPv6Addr{}, err
+	}
+
+	if ones != eui64PrefixLen {
+		return IPv6Addr{}, fmt.Errorf("Unable to derive an EUI-64 address in %s: %w, prefix length must be %d", ipv6.Network(), ErrInvalidMask, eui64PrefixLen)
+	}
+
+	var iid [8]byte
+	switch len(mac) {
+	case 6:
+		copy(iid[:3], mac[:3])
+		iid[3], iid[4] = 0xff, 0xfe
+		copy(iid[5:], mac[3:])
+	case 8:
+		copy(iid[:], mac)
+	default:
+		return IPv6Addr{}, fmt.Errorf("Unable to derive an EUI-64 address from %+q: not an EUI-48 or EUI-64 address", mac.String())
+	}
+
+	// Invert the universal/local bit.
+	iid[0] ^= 0x02
+
+	b := Uint128(ipv6.NetworkAddress()).Bytes()
+	copy(b[8:], iid[:])
+	return IPv6Addr{
+		Address: IPv6Address(Uint128FromBytes(b)),
+		Mask:    ipv6.Mask,
+		Zone:    ipv6.Zone,
+	}, nil
+}
+
+// IsEUI64 returns true if the interface identifier of the IPv6Addr is a
+// modified EUI-64 identifier derived from an EUI-48 MAC address, i.e. it
+// contains `ff:fe` in its middle octets.  Temporary (privacy) addresses and
+// stable opaque identifiers are not EUI-64 identifiers.
+func (ipv6 IPv6Addr) IsEUI64() bool {
+	b := Uint128(ipv6.Address).Bytes()
+	return b[11] == 0xff && b[12] == 0xfe
+}
+
+// MAC returns the EUI-48 MAC address that the IPv6Addr's modified EUI-64
+// interface identifier was derived from (e.g. `00:11:22:33:44:55` for
+// `fe80::211:22ff:fe33:4455`).  An error is returned if the interface
+// identifier is not an EUI-64 identifier, see IsEUI64().
+func (ipv6 IPv6Addr) MAC() (net.HardwareAddr, error) {
+	if !ipv6.IsEUI64() {
+		return nil, fmt.Errorf("Unable to extract a MAC address from %s: not an EUI-64 interface identifier", ipv6.zonedIPString())
+	}
+
+	b := Uint128(ipv6.Address).Bytes()
+	mac := net.HardwareAddr{b[8] ^ 0x02, b[9], b[10], b[13], b[14], b[15]}
+	return mac, nil
+}
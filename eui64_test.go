@@ -0,0 +1,125 @@
+package sockaddr_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestIPv6Addr_EUI64From(t *testing.T) {
+	tests := []struct {
+		network string
+		mac     net.HardwareAddr
+		output  string
+		fail    bool
+	}{
+		{network: "2001:db8::/64", mac: net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}, output: "2001:db8::211:22ff:fe33:4455/64"},
+		{network: "2001:db8:0:1:aaaa::1/64", mac: net.HardwareAddr{0x02, 0x00, 0x5e, 0x10, 0x00, 0x01}, output: "2001:db8:0:1:0:5eff:fe10:1/64"},
+		{network: "fe80::/64%eth0", mac: net.HardwareAddr{0x52, 0x54, 0x00, 0xab, 0xcd, 0xef}, output: "fe80::5054:ff:feab:cdef%eth0/64"},
+		{network: "2001:db8::/64", mac: net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77}, output: "2001:db8::211:2233:4455:6677/64"},
+		{network: "2001:db8::/48", mac: net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}, fail: true},
+		{network: "2001:db8::/64", mac: net.HardwareAddr{0x00, 0x11, 0x22, 0x33}, fail: true},
+	}
+
+	for _, test := range tests {
+		mac := test.mac
+		slaac, err := sockaddr.MustIPv6Addr(test.network).EUI64From(mac)
+		if test.fail {
+			if err == nil {
+				t.Errorf("expected EUI64From(%s) in %s to fail, received %s", mac, test.network, slaac)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EUI64From(%s) in %s: %v", mac, test.network, err)
+			continue
+		}
+		if slaac.String() != test.output {
+			t.Errorf("EUI64From(%s) in %s: expected %q, received %q", mac, test.network, test.output, slaac)
+		}
+
+		// Only identifiers derived from EUI-48 addresses can be detected.
+		if len(mac) == 6 {
+			if !slaac.IsEUI64() {
+				t.Errorf("%s is not detected as an EUI-64 address", slaac)
+			}
+
+			extracted, err := slaac.MAC()
+			if err != nil || extracted.String() != mac.String() {
+				t.Errorf("MAC() of %s: expected %s, received %s, %v", slaac, mac, extracted, err)
+			}
+		}
+	}
+
+	if _, err := sockaddr.MustIPv6Addr("2001:db8::/48").EUI64From(net.HardwareAddr{0, 1, 2, 3, 4, 5}); !errors.Is(err, sockaddr.ErrInvalidMask) {
+		t.Errorf("expected a /48 to fail with ErrInvalidMask, received %v", err)
+	}
+}
+
+func TestIPv6Addr_MAC(t *testing.T) {
+	tests := []struct {
+		input string
+		mac   string
+	}{
+		{"fe80::211:22ff:fe33:4455", "00:11:22:33:44:55"},
+		{"2001:db8::5054:ff:feab:cdef/64", "52:54:00:ab:cd:ef"},
+		// A temporary (privacy) address
+		{"2001:db8::8d3e:27c1:a5b0:91f2", ""},
+		{"::1", ""},
+	}
+
+	for _, test := range tests {
+		ipv6 := sockaddr.MustIPv6Addr(test.input)
+		mac, err := ipv6.MAC()
+		if test.mac == "" {
+			if err == nil || ipv6.IsEUI64() {
+				t.Errorf("expected MAC() of %s to fail, received %s", test.input, mac)
+			}
+		} else if err != nil || mac.String() != test.mac {
+			t.Errorf("MAC() of %s: expected %s, received %s, %v", test.input, test.mac, mac, err)
+		}
+
+		if attr, err := sockaddr.Attr(ipv6, "mac_from_eui64"); err != nil || attr != test.mac {
+			t.Errorf("mac_from_eui64 of %s: expected %q, received %q, %v", test.input, test.mac, attr, err)
+		}
+	}
+}
+
+func TestIfAddr_EUI64(t *testing.T) {
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+	ifAddrs := sockaddr.IfAddrs{
+		{
+			SockAddr:  sockaddr.MustIPv6Addr("2001:db8::211:22ff:fe33:4455/64"),
+			Interface: net.Interface{Name: "eth0", HardwareAddr: mac},
+		},
+		{
+			SockAddr:  sockaddr.MustIPv6Addr("2001:db8::8d3e:27c1:a5b0:91f2/64"),
+			Interface: net.Interface{Name: "eth0", HardwareAddr: mac},
+		},
+		{
+			SockAddr:  sockaddr.MustIPv4Addr("10.0.0.1/24"),
+			Interface: net.Interface{Name: "eth0", HardwareAddr: mac},
+		},
+	}
+
+	// The SLAAC address is predicted from the interface's MAC, even for a
+	// privacy address in the same /64.
+	for _, ifAddr := range ifAddrs[:2] {
+		if attr, err := ifAddr.Attr("eui64"); err != nil || attr != "2001:db8::211:22ff:fe33:4455/64" {
+			t.Errorf("eui64 of %s: received %q, %v", ifAddr.SockAddr, attr, err)
+		}
+	}
+	if attr, err := ifAddrs[2].Attr("eui64"); err == nil {
+		t.Errorf("expected eui64 of %s to fail, received %q", ifAddrs[2].SockAddr, attr)
+	}
+
+	matched, excluded, err := sockaddr.IfByFlag("eui64", ifAddrs)
+	if err != nil {
+		t.Fatalf("IfByFlag: %v", err)
+	}
+	if len(matched) != 1 || !matched[0].SockAddr.Equal(ifAddrs[0].SockAddr) || len(excluded) != 2 {
+		t.Errorf("IfByFlag(eui64): matched %v, excluded %v", matched, excluded)
+	}
+}
@@ -0,0 +1,171 @@
+package sockaddr
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+)
+
+// SockAddrFlag is a flag.Value that parses its argument with ParseSockAddr()
+// according to Options (e.g. `-bind=0.0.0.0:8500` or
+// `-bind=/var/run/agent.sock`).  The zero value accepts any input accepted by
+// NewSockAddr().
+type SockAddrFlag struct {
+	// Options constrains the accepted input (e.g. its address families or
+	// whether a port is required).
+	Options ParseOptions
+
+	// SockAddr is the parsed value, or nil if the flag was not set.
+	SockAddr SockAddr
+}
+
+// Get implements flag.Getter and returns the SockAddr.
+func (f *SockAddrFlag) Get() interface{} {
+	return f.SockAddr
+}
+
+// Set implements flag.Value.
+func (f *SockAddrFlag) Set(s string) error {
+	sa, err := ParseSockAddr(s, f.Options)
+	if err != nil {
+		return err
+	}
+
+	f.SockAddr = sa
+	return nil
+}
+
+// String implements flag.Value and returns the SockAddr in its text form,
+// which can be passed to Set() again (e.g. UNIX socket paths are unquoted).
+func (f *SockAddrFlag) String() string {
+	if f == nil || f.SockAddr == nil {
+		return ""
+	}
+
+	if tm, ok := f.SockAddr.(encoding.TextMarshaler); ok {
+		if text, err := tm.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	return f.SockAddr.String()
+}
+
+// Type returns the name of the flag's value type for use in usage messages.
+func (f *SockAddrFlag) Type() string {
+	return "sockaddr"
+}
+
+// IPAddrFlag is a flag.Value that parses its argument with ParseIPAddr()
+// according to Options (e.g. `-advertise=10.0.0.5` or
+// `-advertise=[2001:db8::5]:8300`).
+type IPAddrFlag struct {
+	// Options constrains the accepted input (e.g. its address families or
+	// whether a port is required).
+	Options ParseOptions
+
+	// IPAddr is the parsed value, or nil if the flag was not set.
+	IPAddr IPAddr
+}
+
+// Get implements flag.Getter and returns the IPAddr.
+func (f *IPAddrFlag) Get() interface{} {
+	return f.IPAddr
+}
+
+// Set implements flag.Value.
+func (f *IPAddrFlag) Set(s string) error {
+	ipAddr, err := ParseIPAddr(s, f.Options)
+	if err != nil {
+		return err
+	}
+
+	f.IPAddr = ipAddr
+	return nil
+}
+
+// String implements flag.Value and returns the IPAddr as a string.
+func (f *IPAddrFlag) String() string {
+	if f == nil || f.IPAddr == nil {
+		return ""
+	}
+
+	return f.IPAddr.String()
+}
+
+// Type returns the name of the flag's value type for use in usage messages.
+func (f *IPAddrFlag) Type() string {
+	return "ipaddr"
+}
+
+// NetworkListFlag is a flag.Value that collects a list of IP addresses and
+// networks, each parsed with ParseIPAddr() according to Options.  The flag
+// may be repeated, and each argument may contain a comma-separated list (e.g.
+// `-allow=10.0.0.0/8,192.168.0.0/16 -allow=2001:db8::/32`).
+type NetworkListFlag struct {
+	// Options constrains each element of the list (e.g. its address
+	// families).
+	Options ParseOptions
+
+	// Networks is the list of parsed values, in the order they were given.
+	Networks []IPAddr
+}
+
+// Contains returns true if any network in the list contains sa.
+func (f *NetworkListFlag) Contains(sa SockAddr) bool {
+	for _, network := range f.Networks {
+		if network.Contains(sa) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get implements flag.Getter and returns the list of IPAddrs.
+func (f *NetworkListFlag) Get() interface{} {
+	return f.Networks
+}
+
+// Set implements flag.Value and appends every element of the comma-separated
+// list in s.  No element is appended if any element is invalid.
+func (f *NetworkListFlag) Set(s string) error {
+	elems := strings.Split(s, ",")
+	networks := make([]IPAddr, 0, len(elems))
+	for i, elem := range elems {
+		elem = strings.TrimSpace(elem)
+		if elem == "" {
+			return fmt.Errorf("Invalid element %d of network list %+q: empty element", i+1, s)
+		}
+
+		ipAddr, err := ParseIPAddr(elem, f.Options)
+		if err != nil {
+			return fmt.Errorf("Invalid element %d of network list %+q: %w", i+1, s, err)
+		}
+
+		networks = append(networks, ipAddr)
+	}
+
+	f.Networks = append(f.Networks, networks...)
+	return nil
+}
+
+// String implements flag.Value and returns the list as a comma-separated
+// string.
+func (f *NetworkListFlag) String() string {
+	if f == nil {
+		return ""
+	}
+
+	strs := make([]string, 0, len(f.Networks))
+	for _, network := range f.Networks {
+		strs = append(strs, network.String())
+	}
+
+	return strings.Join(strs, ",")
+}
+
+// Type returns the name of the flag's value type for use in usage messages.
+func (f *NetworkListFlag) Type() string {
+	return "networks"
+}
@@ -0,0 +1,146 @@
+package sockaddr_test
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestSockAddrFlag(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   sockaddr.ParseOptions
+		input  string
+		output string
+		fail   bool
+	}{
+		{name: "ipv4", input: "0.0.0.0:8500", output: "0.0.0.0:8500"},
+		{name: "ipv6", input: "[::1]:8500", output: "[::1]:8500"},
+		{name: "unix", input: "/var/run/agent.sock", output: "/var/run/agent.sock"},
+		{name: "hostname", opts: sockaddr.ParseOptions{AllowHostname: true, Strict: true}, input: "consul.service:8500", output: "consul.service:8500"},
+		{name: "hostname not allowed", opts: sockaddr.ParseOptions{Strict: true}, input: "consul.service:8500", fail: true},
+		{name: "family", opts: sockaddr.ParseOptions{Families: sockaddr.TypeIP}, input: "/var/run/agent.sock", fail: true},
+		{name: "port required", opts: sockaddr.ParseOptions{Port: sockaddr.PortRequired}, input: "10.0.0.1", fail: true},
+		{name: "port forbidden", opts: sockaddr.ParseOptions{Port: sockaddr.PortForbidden}, input: "10.0.0.1:80", fail: true},
+		{name: "invalid", opts: sockaddr.ParseOptions{Strict: true}, input: "10.0.0.1/33", fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := &sockaddr.SockAddrFlag{Options: test.opts}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(f, "bind", "")
+
+			err := fs.Parse([]string{"-bind", test.input})
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected %q to fail, received %v", test.input, f.SockAddr)
+				}
+				if !strings.Contains(err.Error(), test.input) {
+					t.Fatalf("expected the error to name %q: %v", test.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if f.String() != test.output {
+				t.Fatalf("expected %q, received %q", test.output, f.String())
+			}
+
+			if f.Get().(sockaddr.SockAddr) != f.SockAddr {
+				t.Fatalf("expected Get() to return the SockAddr")
+			}
+		})
+	}
+
+	var zero sockaddr.SockAddrFlag
+	if zero.String() != "" {
+		t.Fatalf("expected an empty string for an unset flag, received %q", zero.String())
+	}
+}
+
+func TestIPAddrFlag(t *testing.T) {
+	f := &sockaddr.IPAddrFlag{Options: sockaddr.ParseOptions{Families: sockaddr.TypeIPv6, Port: sockaddr.PortForbidden}}
+	if err := f.Set("2001:db8::5"); err != nil {
+		t.Fatalf("unable to set: %v", err)
+	}
+	if f.String() != "2001:db8::5" || f.IPAddr.Type() != sockaddr.TypeIPv6 {
+		t.Fatalf("unexpected value %v", f.IPAddr)
+	}
+
+	for _, input := range []string{"10.0.0.5", "[2001:db8::5]:80", "/tmp/sock"} {
+		if err := f.Set(input); err == nil {
+			t.Fatalf("expected %q to fail", input)
+		}
+	}
+
+	if f.String() != "2001:db8::5" {
+		t.Fatalf("expected a failed Set to keep the previous value, received %q", f.String())
+	}
+}
+
+func TestNetworkListFlag(t *testing.T) {
+	f := &sockaddr.NetworkListFlag{Options: sockaddr.ParseOptions{Strict: true, Port: sockaddr.PortForbidden}}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(f, "allow", "")
+
+	err := fs.Parse([]string{"-allow", "10.0.0.0/8, 192.168.0.0/16", "-allow=2001:db8::/32"})
+	if err != nil {
+		t.Fatalf("unable to parse: %v", err)
+	}
+
+	const expected = "10.0.0.0/8,192.168.0.0/16,2001:db8::/32"
+	if f.String() != expected {
+		t.Fatalf("expected %q, received %q", expected, f.String())
+	}
+
+	if len(f.Get().([]sockaddr.IPAddr)) != 3 {
+		t.Fatalf("expected 3 networks, received %v", f.Get())
+	}
+
+	for _, test := range []struct {
+		addr     string
+		contains bool
+	}{
+		{"10.1.2.3", true},
+		{"192.168.4.5", true},
+		{"2001:db8::1", true},
+		{"172.16.0.1", false},
+		{"2001:db9::1", false},
+	} {
+		if got := f.Contains(sockaddr.MustIPAddr(test.addr)); got != test.contains {
+			t.Errorf("Contains(%q): expected %v, received %v", test.addr, test.contains, got)
+		}
+	}
+
+	tests := []struct {
+		input string
+		elem  string
+	}{
+		{"172.16.0.0/12,bogus", "element 2"},
+		{"172.16.0.0/12,,10.0.0.0/8", "element 2"},
+		{"10.0.0.1:80", "element 1"},
+		{"172.16.0.0/12,172.16.0.0/33", "element 2"},
+	}
+
+	for _, test := range tests {
+		err := f.Set(test.input)
+		if err == nil {
+			t.Fatalf("expected %q to fail", test.input)
+		}
+		if !strings.Contains(err.Error(), test.elem) {
+			t.Fatalf("expected the error for %q to name %s: %v", test.input, test.elem, err)
+		}
+	}
+
+	if f.String() != expected {
+		t.Fatalf("expected failed Sets to leave the list unchanged, received %q", f.String())
+	}
+}
@@ -1,8 +1,11 @@
 module github.com/hashicorp/go-sockaddr
 
+go 1.23
+
 require (
 	github.com/hashicorp/errwrap v1.0.0
 	github.com/mitchellh/cli v1.0.0
 	github.com/mitchellh/go-wordwrap v1.0.0
 	github.com/ryanuber/columnize v2.1.0+incompatible
+	golang.org/x/sys v0.25.0
 )
@@ -0,0 +1,295 @@
+package sockaddr
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Hostname is a SockAddr that contains an unresolved DNS hostname and an
+// optional port (e.g. `consul.service:8500` or `db.internal:5432`).  Use
+// Resolve() to expand a Hostname into the IPv4Addr and IPv6Addr SockAddrs it
+// currently refers to.
+type Hostname struct {
+	SockAddr
+	Host string
+	Port IPPort
+}
+
+// hostnameAttrMap is a map of the Hostname type-specific attributes.
+var hostnameAttrMap map[AttrName]func(Hostname) string
+var hostnameAttrs []AttrName
+
+func init() {
+	hostnameAttrInit()
+}
+
+// NewHostname creates a Hostname from a string.  String can be in the form of
+// a hostname and port (e.g. `db.internal:5432`) or a bare hostname (e.g.
+// `db.internal`, in which case the port is initialized to zero).  Literal IP
+// addresses are rejected, use NewIPAddr() instead.
+func NewHostname(s string) (Hostname, error) {
+	host, port := s, uint64(0)
+	if strings.IndexByte(s, ':') != -1 {
+		var portStr string
+		var err error
+		host, portStr, err = net.SplitHostPort(s)
+		if err != nil {
+			return Hostname{}, &ParseError{Input: s, Kind: KindSyntax, Family: TypeHostname, Cause: err}
+		}
+
+		port, err = strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			return Hostname{}, &ParseError{Input: s, Kind: KindPort, Family: TypeHostname, Cause: fmt.Errorf("invalid port %+q", portStr)}
+		}
+	}
+
+	if !isValidHostname(host) {
+		return Hostname{}, &ParseError{Input: s, Kind: KindSyntax, Family: TypeHostname, Cause: fmt.Errorf("invalid hostname %+q", host)}
+	}
+
+	return Hostname{
+		Host: host,
+		Port: IPPort(port),
+	}, nil
+}
+
+// MustHostname is a helper method that must return a Hostname or panic on
+// invalid input.
+func MustHostname(addr string) Hostname {
+	h, err := NewHostname(addr)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to create a Hostname from %+q: %v", addr, err))
+	}
+	return h
+}
+
+// CmpAddress follows the Cmp() standard protocol and returns:
+//
+// - -1 If the receiver should sort first because its host lexically sorts before arg
+// - 0 if the SockAddr arg is not a Hostname, or is a Hostname with the same host.
+// - 1 If the argument should sort first.
+func (h Hostname) CmpAddress(sa SockAddr) int {
+	hb, ok := sa.(Hostname)
+	if !ok {
+		return sortDeferDecision
+	}
+
+	return strings.Compare(strings.ToLower(h.Host), strings.ToLower(hb.Host))
+}
+
+// CmpPort follows the Cmp() standard protocol and returns:
+//
+//   - -1 If the receiver should sort first because its port is lower than arg
+//   - 0 if the SockAddr arg is not a Hostname, or its port is equal to the
+//     receiving Hostname.
+//   - 1 If the argument should sort first.
+func (h Hostname) CmpPort(sa SockAddr) int {
+	hb, ok := sa.(Hostname)
+	if !ok {
+		return sortDeferDecision
+	}
+
+	switch {
+	case h.Port == hb.Port:
+		return sortDeferDecision
+	case h.Port < hb.Port:
+		return sortReceiverBeforeArg
+	default:
+		return sortArgBeforeReceiver
+	}
+}
+
+// CmpRFC doesn't make sense for an unresolved Hostname, so just return defer
+// decision.
+func (h Hostname) CmpRFC(rfcNum uint, sa SockAddr) int { return sortDeferDecision }
+
+// Contains returns true if sa is a Hostname that is Equal to the receiver.
+func (h Hostname) Contains(sa SockAddr) bool {
+	return h.Equal(sa)
+}
+
+// DialPacketArgs returns the arguments required to be passed to net.Dial()
+// with the `udp` network type.  If the Port is 0, DialPacketArgs() will fail.
+func (h Hostname) DialPacketArgs() (network, dialArgs string) {
+	if h.Port == 0 {
+		return "udp", ""
+	}
+	return "udp", h.String()
+}
+
+// DialStreamArgs returns the arguments required to be passed to net.Dial()
+// with the `tcp` network type.  If the Port is 0, DialStreamArgs() will fail.
+func (h Hostname) DialStreamArgs() (network, dialArgs string) {
+	if h.Port == 0 {
+		return "tcp", ""
+	}
+	return "tcp", h.String()
+}
+
+// Equal returns true if a SockAddr is a Hostname with the same host,
+// compared case-insensitively, and port.
+func (h Hostname) Equal(sa SockAddr) bool {
+	hb, ok := sa.(Hostname)
+	if !ok {
+		return false
+	}
+
+	if !strings.EqualFold(h.Host, hb.Host) {
+		return false
+	}
+
+	if h.Port != hb.Port {
+		return false
+	}
+
+	return true
+}
+
+// IPPort returns the Port number attached to the Hostname
+func (h Hostname) IPPort() IPPort {
+	return h.Port
+}
+
+// ListenPacketArgs returns the arguments required to be passed to
+// net.ListenPacket() with the `udp` network type.
+func (h Hostname) ListenPacketArgs() (network, listenArgs string) {
+	return "udp", net.JoinHostPort(h.Host, strconv.Itoa(int(h.Port)))
+}
+
+// ListenStreamArgs returns the arguments required to be passed to
+// net.Listen() with the `tcp` network type.
+func (h Hostname) ListenStreamArgs() (network, listenArgs string) {
+	return "tcp", net.JoinHostPort(h.Host, strconv.Itoa(int(h.Port)))
+}
+
+// Resolve looks up the Hostname using r and returns one SockAddr per address
+// returned by r.  Each SockAddr is either an IPv4Addr or an IPv6Addr host
+// address with the Hostname's port.  If r is nil, net.DefaultResolver is used.
+// Errors returned by r are wrapped, e.g. use errors.As() to retrieve a
+// *net.DNSError.
+func (h Hostname) Resolve(ctx context.Context, r Resolver) (SockAddrs, error) {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+
+	ipAddrs, err := r.LookupIPAddr(ctx, h.Host)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve %+q: %w", h.Host, err)
+	}
+
+	sas := make(SockAddrs, 0, len(ipAddrs))
+	for _, ipAddr := range ipAddrs {
+		if ipv4 := ipAddr.IP.To4(); ipv4 != nil {
+			sas = append(sas, IPv4Addr{
+				Address: IPv4Address(binary.BigEndian.Uint32(ipv4)),
+				Mask:    IPv4HostMask,
+				Port:    h.Port,
+			})
+			continue
+		}
+
+		if ipv6 := ipAddr.IP.To16(); ipv6 != nil {
+			sas = append(sas, IPv6Addr{
+				Address: IPv6Address(netIPToUint128(ipv6)),
+				Mask:    ipv6HostMask,
+				Port:    h.Port,
+				Zone:    ipAddr.Zone,
+			})
+			continue
+		}
+
+		return nil, fmt.Errorf("unable to resolve %+q: invalid IP address %v", h.Host, ipAddr.IP)
+	}
+
+	return sas, nil
+}
+
+// String returns the host and, if set, the port of the Hostname
+func (h Hostname) String() string {
+	if h.Port == 0 {
+		return h.Host
+	}
+
+	return net.JoinHostPort(h.Host, strconv.Itoa(int(h.Port)))
+}
+
+// Type is used as a type switch and returns TypeHostname
+func (Hostname) Type() SockAddrType {
+	return TypeHostname
+}
+
+// HostnameAttrs returns a list of attributes supported by the Hostname type
+func HostnameAttrs() []AttrName {
+	return hostnameAttrs
+}
+
+// HostnameAttr returns a string representation of an attribute for the given
+// Hostname.
+func HostnameAttr(h Hostname, attrName AttrName) string {
+	fn, found := hostnameAttrMap[attrName]
+	if !found {
+		return ""
+	}
+
+	return fn(h)
+}
+
+// hostnameAttrInit is called once at init()
+func hostnameAttrInit() {
+	// Sorted for human readability
+	hostnameAttrs = []AttrName{
+		"host",
+		"port",
+	}
+
+	hostnameAttrMap = map[AttrName]func(h Hostname) string{
+		"host": func(h Hostname) string {
+			return h.Host
+		},
+		"port": func(h Hostname) string {
+			return fmt.Sprintf("%d", h.Port)
+		},
+	}
+}
+
+// isValidHostname returns true if host is a syntactically valid DNS name (RFC
+// 1123, with underscores permitted for service names) and is not a literal IP
+// address.  A trailing dot is permitted.
+func isValidHostname(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	if host == "" || len(host) > 253 || net.ParseIP(host) != nil {
+		return false
+	}
+
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			switch {
+			case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_':
+			default:
+				return false
+			}
+		}
+	}
+
+	// An all-numeric final label is an invalid IPv4 address (e.g.
+	// `256.0.0.1`), not a hostname.
+	if _, err := strconv.ParseUint(labels[len(labels)-1], 10, 64); err == nil {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,239 @@
+package sockaddr_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestNewHostname(t *testing.T) {
+	tests := []struct {
+		input      string
+		host       string
+		port       sockaddr.IPPort
+		str        string
+		dialStream string
+		listen     string
+		fail       bool
+	}{
+		{
+			input:      "consul.service:8500",
+			host:       "consul.service",
+			port:       8500,
+			str:        "consul.service:8500",
+			dialStream: "consul.service:8500",
+			listen:     "consul.service:8500",
+		},
+		{
+			input:      "db.internal",
+			host:       "db.internal",
+			str:        "db.internal",
+			dialStream: "",
+			listen:     "db.internal:0",
+		},
+		{
+			input:      "localhost:80",
+			host:       "localhost",
+			port:       80,
+			str:        "localhost:80",
+			dialStream: "localhost:80",
+			listen:     "localhost:80",
+		},
+		{
+			input:      "_http._tcp.example.com.",
+			host:       "_http._tcp.example.com.",
+			str:        "_http._tcp.example.com.",
+			dialStream: "",
+			listen:     "_http._tcp.example.com.:0",
+		},
+		{input: "1.2.3.4", fail: true},
+		{input: "1.2.3.4:80", fail: true},
+		{input: "256.0.0.1", fail: true},
+		{input: "[::1]:80", fail: true},
+		{input: "-bad.example.com", fail: true},
+		{input: "bad..example.com", fail: true},
+		{input: "bad example.com", fail: true},
+		{input: "example.com:http", fail: true},
+		{input: "example.com:65536", fail: true},
+		{input: "/tmp/foo.sock", fail: true},
+		{input: "", fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			h, err := sockaddr.NewHostname(test.input)
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected %q to fail, got %v", test.input, h)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if h.Type() != sockaddr.TypeHostname {
+				t.Errorf("wrong type: %s", h.Type())
+			}
+			if h.Host != test.host {
+				t.Errorf("wrong host: %q vs %q", h.Host, test.host)
+			}
+			if h.Port != test.port {
+				t.Errorf("wrong port: %d vs %d", h.Port, test.port)
+			}
+			if h.String() != test.str {
+				t.Errorf("wrong string: %q vs %q", h.String(), test.str)
+			}
+			if network, args := h.DialStreamArgs(); network != "tcp" || args != test.dialStream {
+				t.Errorf("wrong DialStreamArgs: %q %q vs %q", network, args, test.dialStream)
+			}
+			if network, args := h.ListenStreamArgs(); network != "tcp" || args != test.listen {
+				t.Errorf("wrong ListenStreamArgs: %q %q vs %q", network, args, test.listen)
+			}
+		})
+	}
+}
+
+func TestHostname_Equal(t *testing.T) {
+	h := sockaddr.MustHostname("db.internal:5432")
+
+	for _, sa := range []sockaddr.SockAddr{
+		sockaddr.MustHostname("db.internal:5432"),
+		sockaddr.MustHostname("DB.Internal:5432"),
+	} {
+		if !h.Equal(sa) || !h.Contains(sa) {
+			t.Errorf("expected %s to equal %s", h, sa)
+		}
+	}
+
+	for _, sa := range []sockaddr.SockAddr{
+		sockaddr.MustHostname("db.internal"),
+		sockaddr.MustHostname("db.internal:5433"),
+		sockaddr.MustHostname("db2.internal:5432"),
+		sockaddr.MustIPv4Addr("10.0.0.5:5432"),
+		sockaddr.MustUnixSock("/tmp/db.sock"),
+	} {
+		if h.Equal(sa) || h.Contains(sa) {
+			t.Errorf("expected %s to not equal %s", h, sa)
+		}
+	}
+}
+
+func TestHostname_Resolve(t *testing.T) {
+	resolver := sockaddr.StaticResolver{
+		"db.internal":  {"10.0.0.5", "2001:db8::5"},
+		"link.local":   {"fe80::1%eth0"},
+		"bad.internal": {"not-an-ip"},
+	}
+
+	tests := []struct {
+		input string
+		want  []string
+		fail  bool
+	}{
+		{
+			input: "db.internal:5432",
+			want:  []string{"10.0.0.5:5432", "[2001:db8::5]:5432"},
+		},
+		{
+			input: "DB.INTERNAL.",
+			want:  []string{"10.0.0.5", "2001:db8::5"},
+		},
+		{
+			input: "link.local:80",
+			want:  []string{"[fe80::1%eth0]:80"},
+		},
+		{
+			input: "missing.internal:80",
+			fail:  true,
+		},
+		{
+			input: "bad.internal",
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			h := sockaddr.MustHostname(test.input)
+			sas, err := h.Resolve(context.Background(), resolver)
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected %q to fail, got %v", test.input, sas)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to resolve %q: %v", test.input, err)
+			}
+
+			if len(sas) != len(test.want) {
+				t.Fatalf("wrong number of SockAddrs: %v vs %v", sas, test.want)
+			}
+			for i, sa := range sas {
+				if sa.String() != test.want[i] {
+					t.Errorf("[%d] wrong SockAddr: %q vs %q", i, sa.String(), test.want[i])
+				}
+				if sa.Type()&sockaddr.TypeIP == 0 {
+					t.Errorf("[%d] expected an IP type, got %s", i, sa.Type())
+				}
+			}
+		})
+	}
+}
+
+type errResolver struct{ err error }
+
+func (r errResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return nil, r.err
+}
+
+func TestHostname_ResolveError(t *testing.T) {
+	wantErr := errors.New("stub failure")
+	h := sockaddr.MustHostname("db.internal:5432")
+	if _, err := h.Resolve(context.Background(), errResolver{err: wantErr}); !errors.Is(err, wantErr) {
+		t.Fatalf("expected the resolver error to be wrapped, got %v", err)
+	}
+
+	timeout := &net.DNSError{Err: "i/o timeout", Name: "db.internal", IsTimeout: true}
+	_, err := h.Resolve(context.Background(), errResolver{err: timeout})
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) || !dnsErr.IsTimeout {
+		t.Fatalf("expected a wrapped *net.DNSError timeout, got %v", err)
+	}
+
+	_, err = sockaddr.MustHostname("missing.internal:5432").Resolve(context.Background(), sockaddr.StaticResolver{})
+	if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Fatalf("expected a wrapped *net.DNSError not found, got %v", err)
+	}
+
+	// *net.Resolver must satisfy the Resolver interface.
+	var _ sockaddr.Resolver = &net.Resolver{}
+}
+
+func TestHostnameAttrs(t *testing.T) {
+	const expectedNumAttrs = 2
+	attrs := sockaddr.HostnameAttrs()
+	if len(attrs) != expectedNumAttrs {
+		t.Fatalf("wrong number of HostnameAttrs: %d vs %d", len(attrs), expectedNumAttrs)
+	}
+
+	h := sockaddr.MustHostname("db.internal:5432")
+	for attr, want := range map[sockaddr.AttrName]string{
+		"host":   "db.internal",
+		"port":   "5432",
+		"type":   "hostname",
+		"string": "db.internal:5432",
+	} {
+		got, err := sockaddr.Attr(h, attr)
+		if err != nil {
+			t.Fatalf("unable to get attr %q: %v", attr, err)
+		}
+		if got != want {
+			t.Errorf("wrong %q: %q vs %q", attr, got, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package sockaddr
+
+import "iter"
+
+// HostsOption configures the addresses returned by the Hosts() iterators.
+type HostsOption func(*hostsConfig)
+
+// hostsConfig is the set of HostsOptions applied to a Hosts() iterator.  The
+// network and broadcast fields are nil unless set by an option.
+type hostsConfig struct {
+	network   *bool
+	broadcast *bool
+	offset    uint64
+	reverse   bool
+}
+
+// HostsNetwork includes or skips the network address (e.g. `192.168.1.0` in
+// `192.168.1.0/24`).  By default, the network address is skipped for IPv4
+// networks and included for IPv6 networks, matching FirstUsable().  The
+// network address is never skipped in IPv4 /31 and /32 networks or IPv6 /127
+// and /128 networks.
+func HostsNetwork(include bool) HostsOption {
+	return func(c *hostsConfig) {
+		c.network = &include
+	}
+}
+
+// HostsBroadcast includes or skips the broadcast address of an IPv4 network
+// (e.g. `192.168.1.255` in `192.168.1.0/24`).  By default, the broadcast
+// address is skipped, matching LastUsable().  The broadcast address is never
+// skipped in /31 and /32 networks.  IPv6 has no broadcast address, so
+// HostsBroadcast has no effect on IPv6 networks.
+func HostsBroadcast(include bool) HostsOption {
+	return func(c *hostsConfig) {
+		c.broadcast = &include
+	}
+}
+
+// HostsOffset skips the first n addresses, in iteration order.
+func HostsOffset(n uint64) HostsOption {
+	return func(c *hostsConfig) {
+		c.offset = n
+	}
+}
+
+// HostsReverse iterates from the last address to the first.
+func HostsReverse() HostsOption {
+	return func(c *hostsConfig) {
+		c.reverse = true
+	}
+}
+
+// newHostsConfig returns the hostsConfig for opts.
+func newHostsConfig(opts []HostsOption) *hostsConfig {
+	c := &hostsConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Hosts returns an iterator over the host addresses in the IPv4Addr's
+// network, as IPv4Addrs with a /32 mask.  Addresses are generated as the
+// iterator advances.  For example, Hosts() on "192.168.1.10/30" yields
+// "192.168.1.9" and "192.168.1.10".
+func (ipv4 IPv4Addr) Hosts(opts ...HostsOption) iter.Seq[IPAddr] {
+	c := newHostsConfig(opts)
+	first, last := uint32(ipv4.NetworkAddress()), uint32(ipv4.BroadcastAddress())
+	if ipv4.Maskbits() < 31 {
+		if c.network == nil || !*c.network {
+			first++
+		}
+		if c.broadcast == nil || !*c.broadcast {
+			last--
+		}
+	}
+
+	return func(yield func(IPAddr) bool) {
+		count := uint64(last) - uint64(first) + 1
+		for i := c.offset; i < count; i++ {
+			addr := first + uint32(i)
+			if c.reverse {
+				addr = last - uint32(i)
+			}
+
+			if !yield(IPv4Addr{Address: IPv4Address(addr), Mask: IPv4HostMask}) {
+				return
+			}
+		}
+	}
+}
+
+// Hosts returns an iterator over the host addresses in the IPv6Addr's
+// network, as IPv6Addrs with a /128 mask and the IPv6Addr's zone.  Addresses
+// are generated as the iterator advances, so it is safe to use on large
+// networks (e.g. a /64) as long as the caller stops early.
+func (ipv6 IPv6Addr) Hosts(opts ...HostsOption) iter.Seq[IPAddr] {
+	c := newHostsConfig(opts)
+	first, last := Uint128(ipv6.NetworkAddress()), ipv6.lastAddress()
+	if ipv6.Maskbits() < 127 && c.network != nil && !*c.network {
+		first = first.Add(Uint128{Lo: 1})
+	}
+
+	return func(yield func(IPAddr) bool) {
+		// The network may contain 2^128 addresses, so compare the offset
+		// against the distance between the first and last address instead of
+		// counting the addresses.
+		offset := Uint128{Lo: c.offset}
+		if offset.Cmp(last.Sub(first)) > 0 {
+			return
+		}
+
+		addr, end, step := first.Add(offset), last, Uint128{Lo: 1}
+		if c.reverse {
+			addr, end, step = last.Sub(offset), first, Uint128FromInt64(-1)
+		}
+
+		for {
+			if !yield(IPv6Addr{Address: IPv6Address(addr), Mask: ipv6HostMask, Zone: ipv6.Zone}) {
+				return
+			}
+
+			if addr == end {
+				return
+			}
+			addr = addr.Add(step)
+		}
+	}
+}
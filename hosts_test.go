@@ -0,0 +1,131 @@
+package sockaddr_test
+
+import (
+	"reflect"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestIPAddr_Hosts(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  sockaddr.IPAddr
+		opts   []sockaddr.HostsOption
+		limit  int
+		output []string
+	}{
+		{
+			name:   "ipv4 /30",
+			input:  sockaddr.MustIPv4Addr("192.168.1.10/30"),
+			output: []string{"192.168.1.9", "192.168.1.10"},
+		},
+		{
+			name:   "ipv4 /30 network and broadcast",
+			input:  sockaddr.MustIPv4Addr("192.168.1.10/30"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsNetwork(true), sockaddr.HostsBroadcast(true)},
+			output: []string{"192.168.1.8", "192.168.1.9", "192.168.1.10", "192.168.1.11"},
+		},
+		{
+			name:   "ipv4 /29 reverse offset",
+			input:  sockaddr.MustIPv4Addr("10.0.0.0/29"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsReverse(), sockaddr.HostsOffset(2)},
+			output: []string{"10.0.0.4", "10.0.0.3", "10.0.0.2", "10.0.0.1"},
+		},
+		{
+			name:   "ipv4 /31",
+			input:  sockaddr.MustIPv4Addr("10.0.0.0/31"),
+			output: []string{"10.0.0.0", "10.0.0.1"},
+		},
+		{
+			name:   "ipv4 /32",
+			input:  sockaddr.MustIPv4Addr("10.0.0.7:80"),
+			output: []string{"10.0.0.7"},
+		},
+		{
+			name:   "ipv4 offset past end",
+			input:  sockaddr.MustIPv4Addr("10.0.0.0/30"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsOffset(2)},
+			output: nil,
+		},
+		{
+			name:   "ipv4 /0 reverse",
+			input:  sockaddr.MustIPv4Addr("0.0.0.0/0"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsReverse()},
+			limit:  2,
+			output: []string{"255.255.255.254", "255.255.255.253"},
+		},
+		{
+			name:   "ipv6 /126",
+			input:  sockaddr.MustIPv6Addr("2001:db8::/126"),
+			output: []string{"2001:db8::", "2001:db8::1", "2001:db8::2", "2001:db8::3"},
+		},
+		{
+			name:   "ipv6 /126 without network",
+			input:  sockaddr.MustIPv6Addr("2001:db8::/126"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsNetwork(false), sockaddr.HostsBroadcast(false)},
+			output: []string{"2001:db8::1", "2001:db8::2", "2001:db8::3"},
+		},
+		{
+			name:   "ipv6 /127",
+			input:  sockaddr.MustIPv6Addr("2001:db8::/127"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsNetwork(false)},
+			output: []string{"2001:db8::", "2001:db8::1"},
+		},
+		{
+			name:   "ipv6 /64 lazy",
+			input:  sockaddr.MustIPv6Addr("fe80::1%eth0/64"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsOffset(1 << 63)},
+			limit:  2,
+			output: []string{"fe80::8000:0:0:0%eth0", "fe80::8000:0:0:1%eth0"},
+		},
+		{
+			name:   "ipv6 /64 reverse",
+			input:  sockaddr.MustIPv6Addr("2001:db8::/64"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsReverse(), sockaddr.HostsOffset(1)},
+			limit:  2,
+			output: []string{"2001:db8::ffff:ffff:ffff:fffe", "2001:db8::ffff:ffff:ffff:fffd"},
+		},
+		{
+			name:   "ipv6 /0",
+			input:  sockaddr.MustIPv6Addr("::/0"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsReverse()},
+			limit:  1,
+			output: []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		},
+		{
+			name:   "ipv6 offset past end",
+			input:  sockaddr.MustIPv6Addr("2001:db8::/127"),
+			opts:   []sockaddr.HostsOption{sockaddr.HostsOffset(2), sockaddr.HostsReverse()},
+			output: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var hosts func(...sockaddr.HostsOption) func(func(sockaddr.IPAddr) bool)
+			switch v := test.input.(type) {
+			case sockaddr.IPv4Addr:
+				hosts = func(opts ...sockaddr.HostsOption) func(func(sockaddr.IPAddr) bool) { return v.Hosts(opts...) }
+			case sockaddr.IPv6Addr:
+				hosts = func(opts ...sockaddr.HostsOption) func(func(sockaddr.IPAddr) bool) { return v.Hosts(opts...) }
+			}
+
+			var output []string
+			for host := range hosts(test.opts...) {
+				if host.Maskbits() != host.Host().Maskbits() || host.IPPort() != 0 {
+					t.Fatalf("expected a host address without a port, received %v", host)
+				}
+
+				output = append(output, host.String())
+				if test.limit > 0 && len(output) == test.limit {
+					break
+				}
+			}
+
+			if !reflect.DeepEqual(output, test.output) {
+				t.Fatalf("expected %q, received %q", test.output, output)
+			}
+		})
+	}
+}
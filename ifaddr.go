@@ -241,9 +241,23 @@ func ifAddrAttrInit() {
 	ifAddrAttrs = []AttrName{
 		"flags",
 		"name",
+		"eui64",
 	}
 
 	ifAddrAttrMap = map[AttrName]func(ifAddr IfAddr) string{
+		"eui64": func(ifAddr IfAddr) string {
+			ipv6, ok := ifAddr.SockAddr.(IPv6Addr)
+			if !ok {
+				return ""
+			}
+
+			// The SLAAC address of the interface's MAC in the /64
+			slaac, err := ipv6.EUI64From(ifAddr.Interface.HardwareAddr)
+			if err != nil {
+				return ""
+			}
+			return slaac.String()
+		},
 		"flags": func(ifAddr IfAddr) string {
 			return ifAddr.Interface.Flags.String()
 		},
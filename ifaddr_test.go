@@ -518,6 +518,105 @@ func TestIfAddrMath(t *testing.T) {
 			value:     "-1",
 			wantFail:  true,
 		},
+		{
+			name: "ipv4 subnet",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.0.0.1/24"),
+			},
+			operation: "subnet",
+			value:     "26,2",
+			expected:  "10.0.0.128/26",
+		},
+		{
+			name: "ipv4 subnet default index",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.0.0.1/24"),
+			},
+			operation: "subnet",
+			value:     "25",
+			expected:  "10.0.0.0/25",
+		},
+		{
+			name: "ipv4 subnet index out of range",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.0.0.1/24"),
+			},
+			operation: "subnet",
+			value:     "26,4",
+			wantFail:  true,
+		},
+		{
+			name: "ipv4 subnet shorter prefix",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.0.0.1/24"),
+			},
+			operation: "subnet",
+			value:     "16,0",
+			wantFail:  true,
+		},
+		{
+			name: "ipv4 supernet",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.1.2.3/24"),
+			},
+			operation: "supernet",
+			value:     "16",
+			expected:  "10.1.0.0/16",
+		},
+		{
+			name: "ipv4 supernet longer prefix",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.1.2.3/24"),
+			},
+			operation: "supernet",
+			value:     "25",
+			wantFail:  true,
+		},
+		{
+			name: "ipv4 sibling",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv4Addr("10.0.1.7/24"),
+			},
+			operation: "sibling",
+			value:     "",
+			expected:  "10.0.0.0/24",
+		},
+		{
+			name: "ipv6 subnet",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv6Addr("2001:db8::/48"),
+			},
+			operation: "subnet",
+			value:     "64,3",
+			expected:  "2001:db8:0:3::/64",
+		},
+		{
+			name: "ipv6 supernet",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv6Addr("2001:db8:1::/48"),
+			},
+			operation: "supernet",
+			value:     "32",
+			expected:  "2001:db8::/32",
+		},
+		{
+			name: "ipv6 sibling",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustIPv6Addr("2001:db8:1::/48"),
+			},
+			operation: "sibling",
+			value:     "",
+			expected:  "2001:db8::/48",
+		},
+		{
+			name: "unix unsupported subnet",
+			ifAddr: sockaddr.IfAddr{
+				SockAddr: sockaddr.MustUnixSock("/tmp/foo"),
+			},
+			operation: "subnet",
+			value:     "24,0",
+			wantFail:  true,
+		},
 		{
 			name: "unix unsupported operation",
 			ifAddr: sockaddr.IfAddr{
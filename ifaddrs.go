@@ -2,9 +2,7 @@ package sockaddr
 
 import (
 	"encoding/binary"
-	"errors"
 	"fmt"
-	"math/big"
 	"net"
 	"regexp"
 	"sort"
@@ -261,6 +259,16 @@ func GetAllInterfaces() (IfAddrs, error) {
 				return IfAddrs{}, fmt.Errorf("unable to create an IP address from %q", addr.String())
 			}
 
+			// Link-local addresses are only meaningful in the scope of
+			// their interface, so record the interface as the zone.
+			if ipv6Addr, ok := ipAddr.(IPv6Addr); ok {
+				ip := *ipv6Addr.NetIP()
+				if ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+					ipv6Addr.Zone = intf.Name
+					ipAddr = ipv6Addr
+				}
+			}
+
 			ifAddr := IfAddr{
 				SockAddr:  ipAddr,
 				Interface: intf,
@@ -462,6 +470,39 @@ func IfByPort(inputRe string, ifAddrs IfAddrs) (matchedIfs, excludedIfs IfAddrs,
 	return matchedIfs, excludedIfs, nil
 }
 
+// IfByPortRange returns a list of matched and non-matched IfAddrs whose ports
+// are within the port range (e.g. `8000-8100`).  An IPPortRange matches if its
+// entire port range is within the port range.  IfAddrs that have neither a
+// port nor a port range are never matched.
+func IfByPortRange(inputRange string, ifAddrs IfAddrs) (matchedIfs, excludedIfs IfAddrs, err error) {
+	low, high, err := ParsePortRange(inputRange)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to parse port range %+q: %w", inputRange, err)
+	}
+
+	matchedIfs = make(IfAddrs, 0, len(ifAddrs))
+	excludedIfs = make(IfAddrs, 0, len(ifAddrs))
+	for _, addr := range ifAddrs {
+		var matched bool
+		switch v := addr.SockAddr.(type) {
+		case IPv4Addr:
+			matched = low <= v.Port && v.Port <= high
+		case IPv6Addr:
+			matched = low <= v.Port && v.Port <= high
+		case IPPortRange:
+			matched = low <= v.Low && v.High <= high
+		}
+
+		if matched {
+			matchedIfs = append(matchedIfs, addr)
+		} else {
+			excludedIfs = append(excludedIfs, addr)
+		}
+	}
+
+	return matchedIfs, excludedIfs, nil
+}
+
 // IfByRFC returns a list of matched and non-matched IfAddrs that contain the
 // relevant RFC-specified traits.
 func IfByRFC(selectorParam string, ifAddrs IfAddrs) (matched, remainder IfAddrs, err error) {
@@ -476,19 +517,24 @@ func IfByRFC(selectorParam string, ifAddrs IfAddrs) (matched, remainder IfAddrs,
 	rfcNetMap := KnownRFCs()
 	rfcNets, ok := rfcNetMap[uint(inputRFC)]
 	if !ok {
-		return nil, nil, fmt.Errorf("unsupported RFC %d", inputRFC)
+		return nil, nil, fmt.Errorf("%w %d for RFC", ErrUnknownSelector, inputRFC)
 	}
 
 	for _, ifAddr := range ifAddrs {
 		var contained bool
-		for _, rfcNet := range rfcNets {
-			if rfcNet.Contains(ifAddr.SockAddr) {
-				matchedIfAddrs = append(matchedIfAddrs, ifAddr)
-				contained = true
-				break
+		if r, ok := ifAddr.SockAddr.(IPRange); ok {
+			contained = r.isRFC(uint(inputRFC))
+		} else {
+			for _, rfcNet := range rfcNets {
+				if rfcNet.Contains(ifAddr.SockAddr) {
+					contained = true
+					break
+				}
 			}
 		}
-		if !contained {
+		if contained {
+			matchedIfAddrs = append(matchedIfAddrs, ifAddr)
+		} else {
 			remainingIfAddrs = append(remainingIfAddrs, ifAddr)
 		}
 	}
@@ -505,7 +551,7 @@ func IfByRFCs(selectorParam string, ifAddrs IfAddrs) (matched, remainder IfAddrs
 	for _, rfcStr := range strings.Split(selectorParam, "|") {
 		includedRFCIfs, excludedRFCIfs, err := IfByRFC(rfcStr, ifAddrs)
 		if err != nil {
-			return IfAddrs{}, IfAddrs{}, fmt.Errorf("unable to lookup RFC number %q: %v", rfcStr, err)
+			return IfAddrs{}, IfAddrs{}, fmt.Errorf("unable to lookup RFC number %q: %w", rfcStr, err)
 		}
 		includedIfs = append(includedIfs, includedRFCIfs...)
 		excludedIfs = append(excludedIfs, excludedRFCIfs...)
@@ -519,7 +565,7 @@ func IfByRFCs(selectorParam string, ifAddrs IfAddrs) (matched, remainder IfAddrs
 func IfByMaskSize(selectorParam string, ifAddrs IfAddrs) (matchedIfs, excludedIfs IfAddrs, err error) {
 	maskSize, err := strconv.ParseUint(selectorParam, 10, 64)
 	if err != nil {
-		return IfAddrs{}, IfAddrs{}, fmt.Errorf("invalid exclude size argument (%q): %v", selectorParam, err)
+		return IfAddrs{}, IfAddrs{}, fmt.Errorf("%w: invalid size argument (%q): %v", ErrInvalidMask, selectorParam, err)
 	}
 
 	ipIfs, nonIfs := FilterIfByType(ifAddrs, TypeIP)
@@ -533,9 +579,9 @@ func IfByMaskSize(selectorParam string, ifAddrs IfAddrs) (matchedIfs, excludedIf
 
 		switch {
 		case (*ipAddr).Type()&TypeIPv4 != 0 && maskSize > 32:
-			return IfAddrs{}, IfAddrs{}, fmt.Errorf("mask size out of bounds for IPv4 address: %d", maskSize)
+			return IfAddrs{}, IfAddrs{}, fmt.Errorf("%w: mask size out of bounds for IPv4 address: %d", ErrInvalidMask, maskSize)
 		case (*ipAddr).Type()&TypeIPv6 != 0 && maskSize > 128:
-			return IfAddrs{}, IfAddrs{}, fmt.Errorf("mask size out of bounds for IPv6 address: %d", maskSize)
+			return IfAddrs{}, IfAddrs{}, fmt.Errorf("%w: mask size out of bounds for IPv6 address: %d", ErrInvalidMask, maskSize)
 		}
 
 		if (*ipAddr).Maskbits() == int(maskSize) {
@@ -563,10 +609,10 @@ func IfByType(inputTypes string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 	ifTypes := strings.Split(strings.ToLower(inputTypes), "|")
 	for _, ifType := range ifTypes {
 		switch ifType {
-		case "ip", "ipv4", "ipv6", "unix":
+		case "ip", "ipv4", "ipv6", "unix", "hostname", "port_range", "ip_range":
 			// Valid types
 		default:
-			return nil, nil, fmt.Errorf("unsupported type %q %q", ifType, inputTypes)
+			return nil, nil, fmt.Errorf("%w %q for type in %q", ErrUnknownSelector, ifType, inputTypes)
 		}
 	}
 
@@ -582,6 +628,12 @@ func IfByType(inputTypes string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 				matched = true
 			case ifType == "unix" && ifAddr.SockAddr.Type()&TypeUnix != 0:
 				matched = true
+			case ifType == "hostname" && ifAddr.SockAddr.Type()&TypeHostname != 0:
+				matched = true
+			case ifType == "port_range" && ifAddr.SockAddr.Type()&TypeIPPortRange != 0:
+				matched = true
+			case ifType == "ip_range" && ifAddr.SockAddr.Type()&TypeIPRange != 0:
+				matched = true
 			}
 
 			if matched {
@@ -607,7 +659,8 @@ func IfByFlag(inputFlags string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 	matchedAddrs := make(IfAddrs, 0, len(ifAddrs))
 	excludedAddrs := make(IfAddrs, 0, len(ifAddrs))
 
-	var wantForwardable,
+	var wantEUI64,
+		wantForwardable,
 		wantGlobalUnicast,
 		wantInterfaceLocalMulticast,
 		wantLinkLocalMulticast,
@@ -625,6 +678,9 @@ func IfByFlag(inputFlags string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 		case "down":
 			checkFlags = true
 			ifFlags = (ifFlags &^ net.FlagUp)
+		case "eui64":
+			checkAttrs = true
+			wantEUI64 = true
 		case "forwardable":
 			checkAttrs = true
 			wantForwardable = true
@@ -660,7 +716,7 @@ func IfByFlag(inputFlags string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 			checkFlags = true
 			ifFlags = ifFlags | net.FlagUp
 		default:
-			return nil, nil, fmt.Errorf("Unknown interface flag: %+q", flagName)
+			return nil, nil, fmt.Errorf("%w %+q for interface flags", ErrUnknownSelector, flagName)
 		}
 	}
 
@@ -689,6 +745,8 @@ func IfByFlag(inputFlags string, ifAddrs IfAddrs) (matched, remainder IfAddrs, e
 					matched = true
 				case wantForwardable && !IsRFC(ForwardingBlacklist, ifAddr.SockAddr):
 					matched = true
+				case wantEUI64 && ToIPv6Addr(ifAddr.SockAddr) != nil && ToIPv6Addr(ifAddr.SockAddr).IsEUI64():
+					matched = true
 				}
 			}
 		}
@@ -708,7 +766,7 @@ func IfByNetwork(selectorParam string, inputIfAddrs IfAddrs) (IfAddrs, IfAddrs,
 	for _, netStr := range strings.Split(selectorParam, "|") {
 		netAddr, err := NewIPAddr(netStr)
 		if err != nil {
-			return nil, nil, fmt.Errorf("unable to create an IP address from %+q: %v", netStr, err)
+			return nil, nil, fmt.Errorf("unable to create an IP address from %+q: %w", netStr, err)
 		}
 
 		for _, ifAddr := range inputIfAddrs {
@@ -767,17 +825,13 @@ func IfAddrMath(operation, value string, inputIfAddr IfAddr) (IfAddr, error) {
 			}
 
 			ipv6 := *ToIPv6Addr(inputIfAddr.SockAddr)
-			ipv6BigIntA := new(big.Int)
-			ipv6BigIntA.Set(ipv6.Address)
-			ipv6BigIntB := big.NewInt(i)
-
-			ipv6Addr := ipv6BigIntA.Add(ipv6BigIntA, ipv6BigIntB)
-			ipv6Addr.And(ipv6Addr, ipv6HostMask)
+			ipv6Addr := Uint128(ipv6.Address).Add(Uint128FromInt64(i))
 
 			return IfAddr{
 				SockAddr: IPv6Addr{
 					Address: IPv6Address(ipv6Addr),
 					Mask:    ipv6.Mask,
+					Zone:    ipv6.Zone,
 				},
 				Interface: inputIfAddr.Interface,
 			}, nil
@@ -833,36 +887,27 @@ func IfAddrMath(operation, value string, inputIfAddr IfAddr) (IfAddr, error) {
 			}
 
 			ipv6 := *ToIPv6Addr(inputIfAddr.SockAddr)
-			ipv6BigInt := new(big.Int)
-			ipv6BigInt.Set(ipv6.NetworkAddress())
+			ipv6Uint128 := Uint128(ipv6.NetworkAddress())
 
-			mask := new(big.Int)
-			mask.Set(ipv6.Mask)
+			mask := Uint128(ipv6.Mask)
 			if i > 0 {
-				wrappedMask := new(big.Int)
-				wrappedMask.SetInt64(i)
-
-				wrappedMask.AndNot(wrappedMask, mask)
-				ipv6BigInt.Add(ipv6BigInt, wrappedMask)
+				wrappedMask := Uint128FromInt64(i).AndNot(mask)
+				ipv6Uint128 = ipv6Uint128.Add(wrappedMask)
 			} else {
 				// Mask off any bits that exceed the network size.  Subtract the
 				// wrappedMask from the last usable - 1
-				wrappedMask := new(big.Int)
-				wrappedMask.SetInt64(-1 * i)
-				wrappedMask.Sub(wrappedMask, big.NewInt(1))
+				wrappedMask := Uint128FromInt64(-1 * i).Sub(Uint128FromInt64(1))
+				wrappedMask = wrappedMask.AndNot(mask)
 
-				wrappedMask.AndNot(wrappedMask, mask)
-
-				lastUsable := new(big.Int)
-				lastUsable.Set(ipv6.LastUsable().(IPv6Addr).Address)
-
-				ipv6BigInt = lastUsable.Sub(lastUsable, wrappedMask)
+				lastUsable := Uint128(ipv6.LastUsable().(IPv6Addr).Address)
+				ipv6Uint128 = lastUsable.Sub(wrappedMask)
 			}
 
 			return IfAddr{
 				SockAddr: IPv6Addr{
-					Address: IPv6Address(ipv6BigInt),
+					Address: IPv6Address(ipv6Uint128),
 					Mask:    ipv6.Mask,
+					Zone:    ipv6.Zone,
 				},
 				Interface: inputIfAddr.Interface,
 			}, nil
@@ -882,7 +927,7 @@ func IfAddrMath(operation, value string, inputIfAddr IfAddr) (IfAddr, error) {
 			}
 
 			if i > 32 {
-				return IfAddr{}, fmt.Errorf("parameter for operation %q on ipv4 addresses must be between 0 and 32", operation)
+				return IfAddr{}, fmt.Errorf("%w: parameter for operation %q on ipv4 addresses must be between 0 and 32", ErrInvalidMask, operation)
 			}
 
 			ipv4 := *ToIPv4Addr(inputIfAddr.SockAddr)
@@ -913,38 +958,80 @@ func IfAddrMath(operation, value string, inputIfAddr IfAddr) (IfAddr, error) {
 			}
 
 			if i > 128 {
-				return IfAddr{}, fmt.Errorf("parameter for operation %q on ipv6 addresses must be between 0 and 64", operation)
+				return IfAddr{}, fmt.Errorf("%w: parameter for operation %q on ipv6 addresses must be between 0 and 128", ErrInvalidMask, operation)
 			}
 
 			ipv6 := *ToIPv6Addr(inputIfAddr.SockAddr)
 
-			ipv6Mask := net.CIDRMask(int(i), 128)
-			ipv6MaskBigInt := new(big.Int)
-			ipv6MaskBigInt.SetBytes(ipv6Mask)
-
-			maskedIpv6 := ipv6.NetIP().Mask(ipv6Mask)
-			maskedIpv6BigInt := new(big.Int)
-			maskedIpv6BigInt.SetBytes(maskedIpv6)
+			ipv6Mask := uint128Mask(int(i))
+			maskedIpv6 := Uint128(ipv6.Address).And(ipv6Mask)
 
-			maskedIpv6MaskBigInt := new(big.Int)
-			maskedIpv6MaskBigInt.Set(ipv6.Mask)
-
-			if ipv6MaskBigInt.Cmp(maskedIpv6MaskBigInt) == -1 {
-				maskedIpv6MaskBigInt = ipv6MaskBigInt
+			maskedIpv6Mask := Uint128(ipv6.Mask)
+			if ipv6Mask.Cmp(maskedIpv6Mask) == -1 {
+				maskedIpv6Mask = ipv6Mask
 			}
 
 			return IfAddr{
 				SockAddr: IPv6Addr{
-					Address: IPv6Address(maskedIpv6BigInt),
-					Mask:    IPv6Mask(maskedIpv6MaskBigInt),
+					Address: IPv6Address(maskedIpv6),
+					Mask:    IPv6Mask(maskedIpv6Mask),
+					Zone:    ipv6.Zone,
 				},
 				Interface: inputIfAddr.Interface,
 			}, nil
 		default:
 			return IfAddr{}, fmt.Errorf("unsupported type for operation %q: %T", operation, sockType)
 		}
+	case "subnet", "supernet", "sibling":
+		// "subnet" returns the subnet at the given index, expressed as
+		// "<prefix length>,<index>", "supernet" returns the network with the
+		// given prefix length that contains the address and "sibling" returns
+		// the other half of the network's immediate supernet.
+		ipAddr, ok := inputIfAddr.SockAddr.(interface {
+			Subnet(newPrefixLen int, index uint64) (IPAddr, error)
+			Supernet(prefixLen int) (IPAddr, error)
+			Sibling() (IPAddr, error)
+		})
+		if !ok {
+			return IfAddr{}, fmt.Errorf("unsupported type for operation %q: %T", operation, inputIfAddr.SockAddr)
+		}
+
+		var result IPAddr
+		var err error
+		switch strings.ToLower(operation) {
+		case "subnet":
+			lenStr, indexStr := value, "0"
+			if i := strings.IndexByte(value, ','); i != -1 {
+				lenStr, indexStr = value[:i], value[i+1:]
+			}
+
+			prefixLen, lenErr := strconv.ParseUint(lenStr, 10, 8)
+			index, indexErr := strconv.ParseUint(indexStr, 10, 64)
+			if lenErr != nil || indexErr != nil {
+				return IfAddr{}, fmt.Errorf("unable to convert %q to a prefix length and index for operation %q", value, operation)
+			}
+
+			result, err = ipAddr.Subnet(int(prefixLen), index)
+		case "supernet":
+			prefixLen, convErr := strconv.ParseUint(value, 10, 8)
+			if convErr != nil {
+				return IfAddr{}, fmt.Errorf("unable to convert %q to int for operation %q: %v", value, operation, convErr)
+			}
+
+			result, err = ipAddr.Supernet(int(prefixLen))
+		default:
+			result, err = ipAddr.Sibling()
+		}
+		if err != nil {
+			return IfAddr{}, err
+		}
+
+		return IfAddr{
+			SockAddr:  result,
+			Interface: inputIfAddr.Interface,
+		}, nil
 	default:
-		return IfAddr{}, fmt.Errorf("unsupported math operation: %q", operation)
+		return IfAddr{}, fmt.Errorf("%w %q for math operation", ErrUnknownSelector, operation)
 	}
 }
 
@@ -955,7 +1042,7 @@ func IfAddrsMath(operation, value string, inputIfAddrs IfAddrs) (IfAddrs, error)
 	for _, ifAddr := range inputIfAddrs {
 		result, err := IfAddrMath(operation, value, ifAddr)
 		if err != nil {
-			return IfAddrs{}, fmt.Errorf("unable to perform an IPMath operation on %s: %v", ifAddr, err)
+			return IfAddrs{}, fmt.Errorf("unable to perform an IPMath operation on %s: %w", ifAddr, err)
 		}
 		outputAddrs = append(outputAddrs, result)
 	}
@@ -978,6 +1065,8 @@ func IncludeIfs(selectorName, selectorParam string, inputIfAddrs IfAddrs) (IfAdd
 		includedIfs, _, err = IfByNetwork(selectorParam, inputIfAddrs)
 	case "port":
 		includedIfs, _, err = IfByPort(selectorParam, inputIfAddrs)
+	case "port_range":
+		includedIfs, _, err = IfByPortRange(selectorParam, inputIfAddrs)
 	case "rfc", "rfcs":
 		includedIfs, _, err = IfByRFCs(selectorParam, inputIfAddrs)
 	case "size":
@@ -985,7 +1074,7 @@ func IncludeIfs(selectorName, selectorParam string, inputIfAddrs IfAddrs) (IfAdd
 	case "type":
 		includedIfs, _, err = IfByType(selectorParam, inputIfAddrs)
 	default:
-		return IfAddrs{}, fmt.Errorf("invalid include selector %q", selectorName)
+		return IfAddrs{}, fmt.Errorf("%w %q for include", ErrUnknownSelector, selectorName)
 	}
 
 	if err != nil {
@@ -1011,6 +1100,8 @@ func ExcludeIfs(selectorName, selectorParam string, inputIfAddrs IfAddrs) (IfAdd
 		_, excludedIfs, err = IfByNetwork(selectorParam, inputIfAddrs)
 	case "port":
 		_, excludedIfs, err = IfByPort(selectorParam, inputIfAddrs)
+	case "port_range":
+		_, excludedIfs, err = IfByPortRange(selectorParam, inputIfAddrs)
 	case "rfc", "rfcs":
 		_, excludedIfs, err = IfByRFCs(selectorParam, inputIfAddrs)
 	case "size":
@@ -1018,7 +1109,7 @@ func ExcludeIfs(selectorName, selectorParam string, inputIfAddrs IfAddrs) (IfAdd
 	case "type":
 		_, excludedIfs, err = IfByType(selectorParam, inputIfAddrs)
 	default:
-		return IfAddrs{}, fmt.Errorf("invalid exclude selector %q", selectorName)
+		return IfAddrs{}, fmt.Errorf("%w %q for exclude", ErrUnknownSelector, selectorName)
 	}
 
 	if err != nil {
@@ -1089,7 +1180,7 @@ func SortIfBy(selectorParam string, inputIfAddrs IfAddrs) (IfAddrs, error) {
 			sortFuncs[i] = DescIfType
 		default:
 			// Return an empty list for invalid sort types.
-			return IfAddrs{}, fmt.Errorf("unknown sort type: %q", clause)
+			return IfAddrs{}, fmt.Errorf("%w %q for sort", ErrUnknownSelector, clause)
 		}
 	}
 
@@ -1113,7 +1204,7 @@ func UniqueIfAddrsBy(selectorName string, inputIfAddrs IfAddrs) (IfAddrs, error)
 		case "name":
 			out = ifAddr.Name
 		default:
-			return nil, fmt.Errorf("unsupported unique constraint %+q", selectorName)
+			return nil, fmt.Errorf("%w %+q for unique", ErrUnknownSelector, selectorName)
 		}
 
 		switch {
@@ -1173,6 +1264,21 @@ func OffsetIfAddrs(off int, in IfAddrs) (IfAddrs, error) {
 	return in[off:], nil
 }
 
+// UnmapIfAddrs returns a copy of the IfAddrs where IPv4-mapped and
+// IPv4-compatible IPv6 addresses have been replaced by their embedded IPv4
+// address.  All other IfAddrs are returned unmodified.  See IPv6Addr.Unmap().
+func UnmapIfAddrs(in IfAddrs) (IfAddrs, error) {
+	out := make(IfAddrs, 0, len(in))
+	for _, ifAddr := range in {
+		if ipv6, ok := ifAddr.SockAddr.(IPv6Addr); ok {
+			ifAddr.SockAddr = ipv6.Unmap()
+		}
+		out = append(out, ifAddr)
+	}
+
+	return out, nil
+}
+
 func (ifAddr IfAddr) String() string {
 	return fmt.Sprintf("%s %v", ifAddr.SockAddr, ifAddr.Interface)
 }
@@ -1193,7 +1299,7 @@ func parseDefaultIfNameFromRoute(routeOut string) (string, error) {
 		}
 	}
 
-	return "", errors.New("No default interface found")
+	return "", ErrNoDefaultRoute
 }
 
 // parseDefaultIfNameFromIPCmd parses the default interface from ip(8) for
@@ -1209,7 +1315,7 @@ func parseDefaultIfNameFromIPCmd(routeOut string) (string, error) {
 		}
 	}
 
-	return "", errors.New("No default interface found")
+	return "", ErrNoDefaultRoute
 }
 
 // parseDefaultIfNameFromIPCmdAndroid parses the default interface from ip(8) for
@@ -1221,7 +1327,7 @@ func parseDefaultIfNameFromIPCmdAndroid(routeOut string) (string, error) {
 		return ifName, nil
 	}
 
-	return "", errors.New("No default interface found")
+	return "", ErrNoDefaultRoute
 }
 
 // parseIfNameFromIPCmd parses interfaces from ip(8) for
@@ -1286,7 +1392,7 @@ func parseDefaultIPAddrWindowsRoute(routeOut string) (string, error) {
 		}
 	}
 
-	return "", errors.New("No IP on default interface found")
+	return "", fmt.Errorf("%w: no IP on default interface", ErrNoDefaultRoute)
 }
 
 // parseDefaultIfNameWindowsIPConfig parses the output of `ipconfig` to find the
@@ -1313,5 +1419,5 @@ func parseDefaultIfNameWindowsIPConfig(defaultIPAddr, routeOut string) (string,
 		}
 	}
 
-	return "", errors.New("No default interface found with matching IP")
+	return "", fmt.Errorf("%w: no interface with IP %+q", ErrNoDefaultRoute, defaultIPAddr)
 }
@@ -213,6 +213,16 @@ func TestFilterIfByFlags(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "eui64",
+			selector: "eui64",
+			ifAddrs: sockaddr.IfAddrs{
+				sockaddr.IfAddr{
+					Interface: net.Interface{},
+					SockAddr:  sockaddr.MustIPv6Addr("2001:db8::211:22ff:fe33:4455/64"),
+				},
+			},
+		},
 		{
 			name:     "forwardable IPv4",
 			selector: "forwardable",
@@ -699,7 +709,7 @@ func TestGetDefaultInterface(t *testing.T) {
 }
 
 func TestIfAddrAttrs(t *testing.T) {
-	const expectedNumAttrs = 2
+	const expectedNumAttrs = 3
 	attrs := sockaddr.IfAddrAttrs()
 	if len(attrs) != expectedNumAttrs {
 		t.Fatalf("wrong number of attrs")
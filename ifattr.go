@@ -38,16 +38,48 @@ func Attr(sa SockAddr, attrName AttrName) (string, error) {
 				return attrVal, nil
 			}
 		} else if sockType == TypeIPv6 {
+			// Some IPv6 attributes (e.g. zone) are legitimately empty, so
+			// test for the attribute's existence instead of its value.
 			ipv6 := *ToIPv6Addr(sa)
-			attrVal := IPv6AddrAttr(ipv6, attrName)
-			if attrVal != "" {
-				return attrVal, nil
+			if _, found := ipv6AddrAttrMap[attrName]; found {
+				return IPv6AddrAttr(ipv6, attrName), nil
 			}
 		}
 
 	case sockType == TypeUnix:
+		// File system attributes are empty for abstract or missing sockets.
 		us := *ToUnixSock(sa)
-		attrVal := UnixSockAttr(us, attrName)
+		if _, found := unixAttrMap[attrName]; found {
+			return UnixSockAttr(us, attrName), nil
+		}
+
+	case sockType == TypeIPPortRange:
+		r := *ToIPPortRange(sa)
+		if _, found := portRangeAttrMap[attrName]; found {
+			return IPPortRangeAttr(r, attrName), nil
+		}
+
+		// Fall back to the attributes of the address.  The address's port
+		// is always zero and is superseded by the port range attributes.
+		switch attrName {
+		case "port", "string", "type":
+		default:
+			if r.Addr != nil {
+				if attrVal, err := Attr(r.Addr, attrName); err == nil {
+					return attrVal, nil
+				}
+			}
+		}
+
+	case sockType == TypeIPRange:
+		r := *ToIPRange(sa)
+		if _, found := ipRangeAttrMap[attrName]; found {
+			return IPRangeAttr(r, attrName), nil
+		}
+
+	case sockType == TypeHostname:
+		h := *ToHostname(sa)
+		attrVal := HostnameAttr(h, attrName)
 		if attrVal != "" {
 			return attrVal, nil
 		}
@@ -61,5 +93,5 @@ func Attr(sa SockAddr, attrName AttrName) (string, error) {
 		return sa.Type().String(), nil
 	}
 
-	return "", fmt.Errorf("unsupported attribute name %q", attrName)
+	return "", fmt.Errorf("%w %q", ErrUnknownAttribute, attrName)
 }
@@ -59,6 +59,8 @@ func testSockAddrAttr(t *testing.T, sai interface{}) {
 		{"first_usable", true, true, false},
 		{"last_usable", true, true, false},
 		{"octets", true, true, false},
+		{"reverse_dns", true, true, false},
+		{"reverse_zones", true, true, false},
 		// IPv4
 		{"broadcast", true, false, false},
 		{"uint32", true, false, false},
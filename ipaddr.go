@@ -1,9 +1,10 @@
 package sockaddr
 
 import (
+	"errors"
 	"fmt"
-	"math/big"
 	"net"
+	"net/netip"
 	"strings"
 )
 
@@ -18,6 +19,7 @@ const (
 // networks, and socket endpoints.
 type IPAddr interface {
 	SockAddr
+	AddrPort() netip.AddrPort
 	AddressBinString() string
 	AddressHexString() string
 	Cmp(SockAddr) int
@@ -31,8 +33,10 @@ type IPAddr interface {
 	NetIP() *net.IP
 	NetIPMask() *net.IPMask
 	NetIPNet() *net.IPNet
+	NetipAddr() netip.Addr
 	Network() IPAddr
 	Octets() []int
+	Prefix() netip.Prefix
 }
 
 // IPPort is the type for an IP port number for the TCP and UDP IP transports.
@@ -53,17 +57,31 @@ func init() {
 // NewIPAddr creates a new IPAddr from a string.  Returns nil if the string is
 // not an IPv4 or an IPv6 address.
 func NewIPAddr(addr string) (IPAddr, error) {
-	ipv4Addr, err := NewIPv4Addr(addr)
-	if err == nil {
+	ipv4Addr, ipv4Err := NewIPv4Addr(addr)
+	if ipv4Err == nil {
 		return ipv4Addr, nil
 	}
 
-	ipv6Addr, err := NewIPv6Addr(addr)
-	if err == nil {
+	ipv6Addr, ipv6Err := NewIPv6Addr(addr)
+	if ipv6Err == nil {
 		return ipv6Addr, nil
 	}
 
-	return nil, fmt.Errorf("invalid IPAddr %v", addr)
+	return nil, ipAddrParseError(addr, ipv4Err, ipv6Err)
+}
+
+// ipAddrParseError returns the more specific of the errors returned by
+// NewIPv4Addr() and NewIPv6Addr() for addr (e.g. an invalid mask on an
+// otherwise valid IPv6 address), or a generic syntax error.
+func ipAddrParseError(addr string, errs ...error) error {
+	for _, err := range errs {
+		var pe *ParseError
+		if errors.As(err, &pe) && pe.Kind != KindSyntax && pe.Kind != KindFamily {
+			return err
+		}
+	}
+
+	return &ParseError{Input: addr, Kind: KindSyntax, Family: TypeIP}
 }
 
 // IPAddrAttr returns a string representation of an attribute for the given
@@ -140,10 +158,8 @@ func ipAddrInit() {
 				}
 				return ipv4Mask.String()
 			case IPv6Addr:
-				ipv6Mask := new(big.Int)
-				ipv6Mask.Set(v.Mask)
 				ipv6MaskAddr := IPv6Addr{
-					Address: IPv6Address(ipv6Mask),
+					Address: IPv6Address(v.Mask),
 					Mask:    ipv6HostMask,
 				}
 				return ipv6MaskAddr.String()
@@ -0,0 +1,481 @@
+package sockaddr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// IPRange is a SockAddr that contains an inclusive range of IPv4 or IPv6
+// addresses (e.g. `10.0.0.10-10.0.0.200` or `2001:db8::10-2001:db8::ff`), as
+// commonly found in DHCP pools, cloud allowlists and firewall exports.  First
+// and Last are host addresses of the same family, without a port, and First is
+// never greater than Last.  See Prefixes() to convert an IPRange to CIDRs and
+// IPRangeFromNetwork() for the reverse.
+type IPRange struct {
+	SockAddr
+	First IPAddr
+	Last  IPAddr
+}
+
+// ipRangeAttrMap is a map of the IPRange type-specific attributes.
+var ipRangeAttrMap map[AttrName]func(IPRange) string
+var ipRangeAttrs []AttrName
+
+func init() {
+	ipRangeAttrInit()
+}
+
+// NewIPRange creates an IPRange from a string.  String must be in the form of
+// two IPv4 or two IPv6 host addresses separated by a dash (e.g.
+// `10.0.0.10-10.0.0.200` or `2001:db8::10-2001:db8::ff`).
+func NewIPRange(s string) (IPRange, error) {
+	return parseIPRange(s, ParseOptions{})
+}
+
+// MustIPRange is a helper method that must return an IPRange or panic on
+// invalid input.
+func MustIPRange(addr string) IPRange {
+	r, err := NewIPRange(addr)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to create an IPRange from %+q: %v", addr, err))
+	}
+	return r
+}
+
+// IPRangeFromAddrs creates an IPRange from its first and last addresses.  Both
+// must be host addresses of the same family without a port, and first must
+// not be greater than last.
+func IPRangeFromAddrs(first, last IPAddr) (IPRange, error) {
+	if _, err := checkIPRange(first, last); err != nil {
+		return IPRange{}, fmt.Errorf("Unable to create an IPRange from %v and %v: %w", first, last, err)
+	}
+
+	return IPRange{
+		First: first,
+		Last:  last,
+	}, nil
+}
+
+// IPRangeFromNetwork returns the IPRange that spans the network of ipAddr,
+// from its network address to its last address (e.g. `10.0.0.0/24` returns
+// `10.0.0.0-10.0.0.255`).  The port of ipAddr is ignored.
+func IPRangeFromNetwork(ipAddr IPAddr) IPRange {
+	first, last, bits, zone := ipAddrBounds(ipAddr)
+	if bits == 0 {
+		return IPRange{}
+	}
+
+	return IPRange{
+		First: newIPRangePrefix(first, bits, bits, zone),
+		Last:  newIPRangePrefix(last, bits, bits, zone),
+	}
+}
+
+// parseIPRange creates an IPRange from a string, parsing both addresses
+// according to opts.  Zones may contain a '-' (e.g.
+// `fe80::1%eth-0-fe80::2%eth-0`), so the range is split on the first '-' that
+// leaves two valid addresses.
+func parseIPRange(s string, opts ParseOptions) (IPRange, error) {
+	if !strings.Contains(s, "-") {
+		return IPRange{}, &ParseError{Input: s, Kind: KindSyntax, Family: TypeIPRange, Cause: errors.New("missing '-' between the first and last addresses")}
+	}
+
+	var addrs [2]IPAddr
+	var firstErr error
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' {
+			continue
+		}
+
+		var err error
+		addrs, err = parseIPRangeAddrs(s[:i], s[i+1:], opts)
+		if err == nil {
+			firstErr = nil
+			break
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		kind := KindSyntax
+		var pe *ParseError
+		if errors.As(firstErr, &pe) {
+			kind = pe.Kind
+		}
+		return IPRange{}, &ParseError{Input: s, Kind: kind, Family: TypeIPRange, Cause: firstErr}
+	}
+
+	if kind, err := checkIPRange(addrs[0], addrs[1]); err != nil {
+		return IPRange{}, &ParseError{Input: s, Kind: kind, Family: TypeIPRange, Cause: err}
+	}
+
+	return IPRange{
+		First: addrs[0],
+		Last:  addrs[1],
+	}, nil
+}
+
+// parseIPRangeAddrs parses the first and last addresses of a range according
+// to opts.
+func parseIPRangeAddrs(firstStr, lastStr string, opts ParseOptions) (addrs [2]IPAddr, err error) {
+	for i, addrStr := range []string{firstStr, lastStr} {
+		addrs[i], err = parseIPAddr(addrStr, opts)
+		if err != nil {
+			return [2]IPAddr{}, err
+		}
+	}
+
+	return addrs, nil
+}
+
+// checkIPRange returns an error, and the part of the range it refers to, if
+// first and last can not form an IPRange.
+func checkIPRange(first, last IPAddr) (ParseErrorKind, error) {
+	if first == nil || last == nil {
+		return KindSyntax, errors.New("missing address")
+	}
+
+	if first.Type() != last.Type() {
+		return KindFamily, fmt.Errorf("%s and %s are of different address families", first, last)
+	}
+
+	for _, ipAddr := range []IPAddr{first, last} {
+		if ipAddr.IPPort() != 0 {
+			return KindPort, fmt.Errorf("address %s can not have a port", ipAddr)
+		}
+
+		if _, _, bits, _ := ipAddrBounds(ipAddr); ipAddr.Maskbits() != bits {
+			return KindMask, fmt.Errorf("%w: %s is not a host address", ErrInvalidMask, ipAddr)
+		}
+	}
+
+	firstAddr, _, _, firstZone := ipAddrBounds(first)
+	lastAddr, _, _, lastZone := ipAddrBounds(last)
+	if firstZone != lastZone {
+		return KindZone, fmt.Errorf("zones %+q and %+q do not match", firstZone, lastZone)
+	}
+
+	if firstAddr.Cmp(lastAddr) > 0 {
+		return KindSyntax, fmt.Errorf("first address %s is greater than last address %s", first, last)
+	}
+
+	return KindSyntax, nil
+}
+
+// CmpAddress follows the Cmp() standard protocol and returns:
+//
+//   - -1 If the receiver should sort first because its first address (or, if
+//     both start at the same address, its last address) is lower than arg's
+//   - 0 if the SockAddr arg is not an IPRange of the same family, or its
+//     range is equal to the receiving IPRange.
+//   - 1 If the argument should sort first.
+func (r IPRange) CmpAddress(sa SockAddr) int {
+	rb, ok := sa.(IPRange)
+	if !ok {
+		return sortDeferDecision
+	}
+
+	first, last, bits, _ := r.bounds()
+	firstB, lastB, bitsB, _ := rb.bounds()
+	if bits == 0 || bits != bitsB {
+		return sortDeferDecision
+	}
+
+	cmp := first.Cmp(firstB)
+	if cmp == 0 {
+		cmp = last.Cmp(lastB)
+	}
+
+	switch cmp {
+	case -1:
+		return sortReceiverBeforeArg
+	case 1:
+		return sortArgBeforeReceiver
+	default:
+		return sortDeferDecision
+	}
+}
+
+// CmpRFC follows the Cmp() standard protocol and returns:
+//
+//   - -1 If the receiver should sort first because it is entirely within the
+//     RFC and its arg is not
+//   - 0 if the receiver and arg both belong to the same RFC or neither do.
+//   - 1 If the arg belongs to the RFC but receiver does not.
+func (r IPRange) CmpRFC(rfcNum uint, sa SockAddr) int {
+	recvInRFC := r.isRFC(rfcNum)
+
+	var argInRFC bool
+	if rb, ok := sa.(IPRange); ok {
+		argInRFC = rb.isRFC(rfcNum)
+	} else {
+		argInRFC = IsRFC(rfcNum, sa)
+	}
+
+	switch {
+	case recvInRFC == argInRFC:
+		return sortDeferDecision
+	case recvInRFC:
+		return sortReceiverBeforeArg
+	default:
+		return sortArgBeforeReceiver
+	}
+}
+
+// Contains returns true if sa is contained within the receiver.  An IPv4Addr
+// or IPv6Addr is contained if its whole network is within the range, and an
+// IPRange is contained if it is a subrange of the receiver.  If the receiver
+// has a zone, sa must have the same zone in order to be contained.
+func (r IPRange) Contains(sa SockAddr) bool {
+	first, last, bits, zone := r.bounds()
+	if bits == 0 {
+		return false
+	}
+
+	var firstB, lastB Uint128
+	var bitsB int
+	var zoneB string
+	switch v := sa.(type) {
+	case IPRange:
+		firstB, lastB, bitsB, zoneB = v.bounds()
+	case IPv4Addr, IPv6Addr:
+		firstB, lastB, bitsB, zoneB = ipAddrBounds(v.(IPAddr))
+	default:
+		return false
+	}
+
+	if bits != bitsB || (zone != "" && zone != zoneB) {
+		return false
+	}
+
+	return first.Cmp(firstB) <= 0 && last.Cmp(lastB) >= 0
+}
+
+// DialPacketArgs returns the arguments required to be passed to
+// net.DialUDP().  DialPacketArgs() always fails because an IPRange has no
+// port.
+func (r IPRange) DialPacketArgs() (network, dialArgs string) {
+	network, _ = r.single().DialPacketArgs()
+	return network, ""
+}
+
+// DialStreamArgs returns the arguments required to be passed to
+// net.DialTCP().  DialStreamArgs() always fails because an IPRange has no
+// port.
+func (r IPRange) DialStreamArgs() (network, dialArgs string) {
+	network, _ = r.single().DialStreamArgs()
+	return network, ""
+}
+
+// Equal returns true if a SockAddr is an IPRange with equal first and last
+// addresses.
+func (r IPRange) Equal(sa SockAddr) bool {
+	rb, ok := sa.(IPRange)
+	if !ok || r.First == nil || rb.First == nil {
+		return false
+	}
+
+	return r.First.Equal(rb.First) && r.Last.Equal(rb.Last)
+}
+
+// ListenPacketArgs returns the arguments required to be passed to
+// net.ListenUDP().  ListenPacketArgs() will fail unless the IPRange contains a
+// single address.
+func (r IPRange) ListenPacketArgs() (network, listenArgs string) {
+	network, listenArgs = r.single().ListenPacketArgs()
+	if r.First == nil || !r.First.Equal(r.Last) {
+		return network, ""
+	}
+	return network, listenArgs
+}
+
+// ListenStreamArgs returns the arguments required to be passed to
+// net.ListenTCP().  ListenStreamArgs() will fail unless the IPRange contains a
+// single address.
+func (r IPRange) ListenStreamArgs() (network, listenArgs string) {
+	network, listenArgs = r.single().ListenStreamArgs()
+	if r.First == nil || !r.First.Equal(r.Last) {
+		return network, ""
+	}
+	return network, listenArgs
+}
+
+// Prefixes returns the minimal list of networks that exactly covers the
+// range, in ascending order (e.g. `10.0.0.10-10.0.0.20` returns
+// `10.0.0.10/31`, `10.0.0.12/30`, `10.0.0.16/30` and `10.0.0.20`).
+func (r IPRange) Prefixes() IPAddrs {
+	first, last, bits, zone := r.bounds()
+	if bits == 0 || first.Cmp(last) > 0 {
+		return nil
+	}
+
+	var prefixes IPAddrs
+	for {
+		// The largest network that starts at first and ends within the
+		// range.
+		hostBits := min(first.TrailingZeros(), bits)
+		for first.Or(uint128Mask(128-hostBits).Not()).Cmp(last) > 0 {
+			hostBits--
+		}
+
+		end := first.Or(uint128Mask(128 - hostBits).Not())
+		prefixes = append(prefixes, newIPRangePrefix(first, bits-hostBits, bits, zone))
+		if end.Cmp(last) == 0 {
+			return prefixes
+		}
+
+		first = end.Add(Uint128FromInt64(1))
+	}
+}
+
+// Size returns the number of addresses in the range.  A *big.Int is returned
+// because the size of the whole IPv6 address space does not fit in a Uint128.
+func (r IPRange) Size() *big.Int {
+	first, last, bits, _ := r.bounds()
+	if bits == 0 {
+		return big.NewInt(0)
+	}
+
+	size := last.Sub(first).BigInt()
+	return size.Add(size, big.NewInt(1))
+}
+
+// String returns the first and last addresses of the IPRange separated by a
+// dash (e.g. `10.0.0.10-10.0.0.200`).
+func (r IPRange) String() string {
+	if r.First == nil || r.Last == nil {
+		return "-"
+	}
+
+	return r.First.String() + "-" + r.Last.String()
+}
+
+// Type is used as a type switch and returns TypeIPRange
+func (IPRange) Type() SockAddrType {
+	return TypeIPRange
+}
+
+// bounds returns the first and last addresses of the range, the number of bits
+// in its address family and its zone.  bits is zero if the IPRange has no
+// addresses.
+func (r IPRange) bounds() (first, last Uint128, bits int, zone string) {
+	if r.First == nil || r.Last == nil {
+		return Uint128{}, Uint128{}, 0, ""
+	}
+
+	first, _, bits, zone = ipAddrBounds(r.First)
+	_, last, _, _ = ipAddrBounds(r.Last)
+	return first, last, bits, zone
+}
+
+// isRFC returns true if the whole range is within one of the networks of the
+// RFC.
+func (r IPRange) isRFC(rfcNum uint) bool {
+	for _, rfcNet := range KnownRFCs()[rfcNum] {
+		if ipAddr, ok := rfcNet.(IPAddr); ok && IPRangeFromNetwork(ipAddr).Contains(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// single returns the first address of the range.
+func (r IPRange) single() IPAddr {
+	if r.First == nil {
+		return IPv4Addr{}
+	}
+
+	return r.First
+}
+
+// ipAddrBounds returns the first and last addresses of the network of ipAddr,
+// the number of bits in its address family and its zone.  IPv4 addresses are
+// returned in the low 32 bits of the Uint128.  bits is zero if ipAddr is not
+// an IPv4Addr or IPv6Addr.
+func ipAddrBounds(ipAddr IPAddr) (first, last Uint128, bits int, zone string) {
+	switch v := ipAddr.(type) {
+	case IPv4Addr:
+		first = Uint128{Lo: uint64(v.NetworkAddress())}
+		last = Uint128{Lo: uint64(v.BroadcastAddress())}
+		return first, last, IPv4len * 8, ""
+	case IPv6Addr:
+		return Uint128(v.NetworkAddress()), v.lastAddress(), IPv6len * 8, v.Zone
+	default:
+		return Uint128{}, Uint128{}, 0, ""
+	}
+}
+
+// newIPRangePrefix returns the network of the address family with bits bits
+// that starts at addr and has a prefix length of prefixLen.
+func newIPRangePrefix(addr Uint128, prefixLen, bits int, zone string) IPAddr {
+	if bits == IPv4len*8 {
+		return IPv4Addr{
+			Address: IPv4Address(addr.Lo),
+			Mask:    IPv4Mask(ipv4HostMaskLen(prefixLen)),
+		}
+	}
+
+	return IPv6Addr{
+		Address: IPv6Address(addr),
+		Mask:    IPv6Mask(uint128Mask(prefixLen)),
+		Zone:    zone,
+	}
+}
+
+// IPRangeAttrs returns a list of attributes supported by the IPRange type.
+func IPRangeAttrs() []AttrName {
+	return ipRangeAttrs
+}
+
+// IPRangeAttr returns a string representation of an attribute for the given
+// IPRange.
+func IPRangeAttr(r IPRange, attrName AttrName) string {
+	fn, found := ipRangeAttrMap[attrName]
+	if !found {
+		return ""
+	}
+
+	return fn(r)
+}
+
+// ipRangeAttrInit is called once at init()
+func ipRangeAttrInit() {
+	// Sorted for human readability
+	ipRangeAttrs = []AttrName{
+		"first",
+		"last",
+		"size",
+		"prefixes",
+	}
+
+	ipRangeAttrMap = map[AttrName]func(r IPRange) string{
+		"first": func(r IPRange) string {
+			if r.First == nil {
+				return ""
+			}
+			return r.First.String()
+		},
+		"last": func(r IPRange) string {
+			if r.Last == nil {
+				return ""
+			}
+			return r.Last.String()
+		},
+		"prefixes": func(r IPRange) string {
+			prefixes := r.Prefixes()
+			strs := make([]string, 0, len(prefixes))
+			for _, prefix := range prefixes {
+				strs = append(strs, prefix.String())
+			}
+			return strings.Join(strs, " ")
+		},
+		"size": func(r IPRange) string {
+			return r.Size().Text(10)
+		},
+	}
+}
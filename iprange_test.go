@@ -0,0 +1,324 @@
+package sockaddr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestNewIPRange(t *testing.T) {
+	tests := []struct {
+		input string
+		first string
+		last  string
+		size  string
+		kind  sockaddr.ParseErrorKind
+		fail  bool
+	}{
+		{input: "10.0.0.10-10.0.0.200", first: "10.0.0.10", last: "10.0.0.200", size: "191"},
+		{input: "10.0.0.1-10.0.0.1", first: "10.0.0.1", last: "10.0.0.1", size: "1"},
+		{input: "0.0.0.0-255.255.255.255", first: "0.0.0.0", last: "255.255.255.255", size: "4294967296"},
+		{input: "2001:db8::10-2001:db8::ff", first: "2001:db8::10", last: "2001:db8::ff", size: "240"},
+		{input: "::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", first: "::", last: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", size: "340282366920938463463374607431768211456"},
+		{input: "fe80::1%eth0-fe80::ff%eth0", first: "fe80::1%eth0", last: "fe80::ff%eth0", size: "255"},
+		{input: "fe80::1%eth-0-fe80::2%eth-0", first: "fe80::1%eth-0", last: "fe80::2%eth-0", size: "2"},
+		{input: "10.0.0.200-10.0.0.10", kind: sockaddr.KindSyntax, fail: true},
+		{input: "10.0.0.1-2001:db8::1", kind: sockaddr.KindFamily, fail: true},
+		{input: "10.0.0.0/24-10.0.1.0", kind: sockaddr.KindMask, fail: true},
+		{input: "10.0.0.1:80-10.0.0.2", kind: sockaddr.KindPort, fail: true},
+		{input: "fe80::1%eth0-fe80::ff%eth1", kind: sockaddr.KindZone, fail: true},
+		{input: "10.0.0.1", kind: sockaddr.KindSyntax, fail: true},
+		{input: "10.0.0.1-", kind: sockaddr.KindSyntax, fail: true},
+		{input: "10.0.0.1-10.0.0.2-10.0.0.3", kind: sockaddr.KindSyntax, fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			r, err := sockaddr.NewIPRange(test.input)
+			if test.fail {
+				var pe *sockaddr.ParseError
+				if !errors.As(err, &pe) {
+					t.Fatalf("expected %q to fail with a ParseError, got %v (%v)", test.input, r, err)
+				}
+				if pe.Kind != test.kind {
+					t.Fatalf("wrong error kind: %s vs %s: %v", pe.Kind, test.kind, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if r.First.String() != test.first || r.Last.String() != test.last {
+				t.Errorf("wrong range: %s-%s vs %s-%s", r.First, r.Last, test.first, test.last)
+			}
+			if r.Size().String() != test.size {
+				t.Errorf("wrong size: %s vs %s", r.Size(), test.size)
+			}
+			if r.String() != test.input {
+				t.Errorf("wrong string: %q vs %q", r.String(), test.input)
+			}
+
+			sa, err := sockaddr.NewSockAddr(test.input)
+			if err != nil {
+				t.Fatalf("NewSockAddr unable to parse %q: %v", test.input, err)
+			}
+			if sa.Type() != sockaddr.TypeIPRange || !sa.Equal(r) {
+				t.Errorf("NewSockAddr mismatch: %v (%s)", sa, sa.Type())
+			}
+		})
+	}
+}
+
+func TestIPRangeFromAddrs(t *testing.T) {
+	r, err := sockaddr.IPRangeFromAddrs(sockaddr.MustIPv4Addr("10.0.0.1"), sockaddr.MustIPv4Addr("10.0.0.9"))
+	if err != nil || r.String() != "10.0.0.1-10.0.0.9" {
+		t.Fatalf("expected 10.0.0.1-10.0.0.9, received %v, %v", r, err)
+	}
+
+	if _, err := sockaddr.IPRangeFromAddrs(sockaddr.MustIPv4Addr("10.0.0.9"), sockaddr.MustIPv4Addr("10.0.0.1")); err == nil {
+		t.Errorf("expected a reversed range to fail")
+	}
+
+	if _, err := sockaddr.IPRangeFromAddrs(nil, sockaddr.MustIPv4Addr("10.0.0.1")); err == nil {
+		t.Errorf("expected a range without a first address to fail")
+	}
+}
+
+func TestIPRange_Prefixes(t *testing.T) {
+	tests := []struct {
+		input    string
+		prefixes []string
+	}{
+		{
+			input:    "10.0.0.10-10.0.0.20",
+			prefixes: []string{"10.0.0.10/31", "10.0.0.12/30", "10.0.0.16/30", "10.0.0.20"},
+		},
+		{
+			input:    "10.0.0.0-10.0.0.255",
+			prefixes: []string{"10.0.0.0/24"},
+		},
+		{
+			input:    "10.0.0.255-10.0.1.0",
+			prefixes: []string{"10.0.0.255", "10.0.1.0"},
+		},
+		{
+			input:    "0.0.0.0-255.255.255.255",
+			prefixes: []string{"0.0.0.0/0"},
+		},
+		{
+			input:    "255.255.255.254-255.255.255.255",
+			prefixes: []string{"255.255.255.254/31"},
+		},
+		{
+			input:    "2001:db8::-2001:db8::1:0",
+			prefixes: []string{"2001:db8::/112", "2001:db8::1:0"},
+		},
+		{
+			input:    "::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+			prefixes: []string{"::/0"},
+		},
+		{
+			input:    "::1-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+			prefixes: []string{"::1", "::2/127", "::4/126"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			r := sockaddr.MustIPRange(test.input)
+			prefixes := r.Prefixes()
+
+			var strs []string
+			for _, prefix := range prefixes {
+				strs = append(strs, prefix.String())
+			}
+
+			if len(test.prefixes) == 3 && len(strs) > 3 {
+				// Only the first prefixes of very large decompositions
+				// are listed.
+				if len(strs) != 128 {
+					t.Fatalf("expected 128 prefixes, received %d", len(strs))
+				}
+				strs = strs[:3]
+			}
+
+			if !reflect.DeepEqual(strs, test.prefixes) {
+				t.Fatalf("expected %q, received %q", test.prefixes, strs)
+			}
+
+			// The prefixes are contiguous and exactly cover the range.
+			for _, prefix := range prefixes {
+				if !r.Contains(prefix) {
+					t.Errorf("%s does not contain prefix %s", r, prefix)
+				}
+			}
+			if first := sockaddr.IPRangeFromNetwork(prefixes[0]).First; !first.Equal(r.First) {
+				t.Errorf("first prefix starts at %s, not %s", first, r.First)
+			}
+			if last := sockaddr.IPRangeFromNetwork(prefixes[len(prefixes)-1]).Last; !last.Equal(r.Last) {
+				t.Errorf("last prefix ends at %s, not %s", last, r.Last)
+			}
+		})
+	}
+}
+
+func TestIPRangeFromNetwork(t *testing.T) {
+	tests := []struct {
+		input  sockaddr.IPAddr
+		output string
+	}{
+		{sockaddr.MustIPv4Addr("10.0.0.5/24"), "10.0.0.0-10.0.0.255"},
+		{sockaddr.MustIPv4Addr("10.0.0.5:80"), "10.0.0.5-10.0.0.5"},
+		{sockaddr.MustIPv6Addr("2001:db8::/64"), "2001:db8::-2001:db8::ffff:ffff:ffff:ffff"},
+		{sockaddr.MustIPv6Addr("fe80::/64%eth0"), "fe80::%eth0-fe80::ffff:ffff:ffff:ffff%eth0"},
+	}
+
+	for _, test := range tests {
+		r := sockaddr.IPRangeFromNetwork(test.input)
+		if r.String() != test.output {
+			t.Errorf("IPRangeFromNetwork(%s): expected %q, received %q", test.input, test.output, r)
+		}
+
+		if prefixes := r.Prefixes(); len(prefixes) != 1 || !prefixes[0].Contains(test.input) {
+			t.Errorf("IPRangeFromNetwork(%s): %s does not decompose to its network: %v", test.input, r, prefixes)
+		}
+	}
+}
+
+func TestIPRange_Contains(t *testing.T) {
+	r := sockaddr.MustIPRange("10.0.0.10-10.0.0.200")
+
+	tests := []struct {
+		sa       sockaddr.SockAddr
+		contains bool
+	}{
+		{sockaddr.MustIPv4Addr("10.0.0.10"), true},
+		{sockaddr.MustIPv4Addr("10.0.0.200:80"), true},
+		{sockaddr.MustIPv4Addr("10.0.0.9"), false},
+		{sockaddr.MustIPv4Addr("10.0.0.201"), false},
+		{sockaddr.MustIPv4Addr("10.0.0.64/26"), true},
+		{sockaddr.MustIPv4Addr("10.0.0.0/24"), false},
+		{sockaddr.MustIPRange("10.0.0.20-10.0.0.30"), true},
+		{sockaddr.MustIPRange("10.0.0.5-10.0.0.30"), false},
+		{sockaddr.MustIPv6Addr("::ffff:10.0.0.20"), false},
+		{sockaddr.MustIPv6Addr("2001:db8::20"), false},
+		{sockaddr.MustUnixSock("/tmp/foo"), false},
+	}
+
+	for _, test := range tests {
+		if contains := r.Contains(test.sa); contains != test.contains {
+			t.Errorf("%s.Contains(%s): expected %v", r, test.sa, test.contains)
+		}
+	}
+
+	zoned := sockaddr.MustIPRange("fe80::1%eth0-fe80::ff%eth0")
+	if !zoned.Contains(sockaddr.MustIPv6Addr("fe80::10%eth0")) {
+		t.Errorf("%s should contain fe80::10%%eth0", zoned)
+	}
+	if zoned.Contains(sockaddr.MustIPv6Addr("fe80::10%eth1")) {
+		t.Errorf("%s should not contain fe80::10%%eth1", zoned)
+	}
+}
+
+func TestIPRange_Args(t *testing.T) {
+	r := sockaddr.MustIPRange("10.0.0.10-10.0.0.200")
+	if network, args := r.ListenStreamArgs(); network != "tcp4" || args != "" {
+		t.Errorf("ListenStreamArgs: %q %q", network, args)
+	}
+	if network, args := r.DialPacketArgs(); network != "udp4" || args != "" {
+		t.Errorf("DialPacketArgs: %q %q", network, args)
+	}
+
+	single := sockaddr.MustIPRange("2001:db8::1-2001:db8::1")
+	if network, args := single.ListenPacketArgs(); network != "udp6" || args != "[2001:db8::1]:0" {
+		t.Errorf("ListenPacketArgs: %q %q", network, args)
+	}
+}
+
+func TestIPRange_Sort(t *testing.T) {
+	sas := sockaddr.SockAddrs{
+		sockaddr.MustIPRange("10.0.0.10-10.0.0.20"),
+		sockaddr.MustIPRange("10.0.0.1-10.0.0.200"),
+		sockaddr.MustIPRange("10.0.0.1-10.0.0.100"),
+		sockaddr.MustUnixSock("/tmp/foo"),
+		sockaddr.MustUnixSock("/tmp/bar"),
+	}
+
+	sockaddr.OrderedAddrBy(sockaddr.AscType, sockaddr.AscAddress).Sort(sas)
+	expected := []string{`"/tmp/bar"`, `"/tmp/foo"`, "10.0.0.1-10.0.0.100", "10.0.0.1-10.0.0.200", "10.0.0.10-10.0.0.20"}
+	for i, sa := range sas {
+		if sa.String() != expected[i] {
+			t.Fatalf("AscAddress: expected %q at %d, received %q", expected[i], i, sa)
+		}
+	}
+
+	// AscNetworkSize must not panic on non-IP types.
+	sockaddr.OrderedAddrBy(sockaddr.AscType, sockaddr.AscNetworkSize).Sort(sas)
+	expected = []string{`"/tmp/bar"`, `"/tmp/foo"`, "10.0.0.1-10.0.0.200", "10.0.0.1-10.0.0.100", "10.0.0.10-10.0.0.20"}
+	for i, sa := range sas {
+		if sa.String() != expected[i] {
+			t.Fatalf("AscNetworkSize: expected %q at %d, received %q", expected[i], i, sa)
+		}
+	}
+
+	matched, excluded := sas.FilterByType(sockaddr.TypeIPRange)
+	if len(matched) != 3 || len(excluded) != 2 {
+		t.Errorf("FilterByType: expected 3 ranges, received %v", matched)
+	}
+}
+
+func TestIPRange_IfByRFC(t *testing.T) {
+	ifAddrs := sockaddr.IfAddrs{
+		{SockAddr: sockaddr.MustIPRange("10.0.0.1-10.0.0.200")},
+		{SockAddr: sockaddr.MustIPRange("192.0.2.1-192.0.2.10")},
+		{SockAddr: sockaddr.MustIPRange("172.15.255.255-172.16.0.1")},
+		{SockAddr: sockaddr.MustIPv4Addr("192.168.0.1")},
+	}
+
+	matched, remainder, err := sockaddr.IfByRFC("1918", ifAddrs)
+	if err != nil {
+		t.Fatalf("IfByRFC: %v", err)
+	}
+	if len(matched) != 2 || len(remainder) != 2 {
+		t.Fatalf("IfByRFC: expected 2 matches and 2 remainders, received %v and %v", matched, remainder)
+	}
+	if matched[0].SockAddr.String() != "10.0.0.1-10.0.0.200" || matched[1].SockAddr.String() != "192.168.0.1" {
+		t.Errorf("IfByRFC: unexpected matches %v", matched)
+	}
+}
+
+func TestIPRangeAttrs(t *testing.T) {
+	r := sockaddr.MustIPRange("10.0.0.10-10.0.0.20")
+
+	tests := []struct {
+		name  sockaddr.AttrName
+		value string
+	}{
+		{"first", "10.0.0.10"},
+		{"last", "10.0.0.20"},
+		{"size", "11"},
+		{"prefixes", "10.0.0.10/31 10.0.0.12/30 10.0.0.16/30 10.0.0.20"},
+		{"type", "ip_range"},
+		{"string", "10.0.0.10-10.0.0.20"},
+	}
+
+	for _, test := range tests {
+		value, err := sockaddr.Attr(r, test.name)
+		if err != nil || value != test.value {
+			t.Errorf("Attr(%s): expected %q, received %q, %v", test.name, test.value, value, err)
+		}
+	}
+
+	if len(sockaddr.IPRangeAttrs()) != 4 {
+		t.Errorf("expected 4 IPRange attributes, received %v", sockaddr.IPRangeAttrs())
+	}
+
+	ifAddrs := sockaddr.IfAddrs{{SockAddr: r}, {SockAddr: sockaddr.MustIPv4Addr("10.0.0.1")}}
+	matched, _, err := sockaddr.IfByType("ip_range", ifAddrs)
+	if err != nil || len(matched) != 1 || !matched[0].SockAddr.Equal(r) {
+		t.Errorf("IfByType(ip_range): received %v, %v", matched, err)
+	}
+}
@@ -55,6 +55,8 @@ func init() {
 // To create uint32 values from net.IP, always test to make sure the address
 // returned can be converted to a 4 byte array using To4().
 func NewIPv4Addr(ipv4Str string) (IPv4Addr, error) {
+	input := ipv4Str
+
 	// Strip off any bogus hex-encoded netmasks that will be mis-parsed by Go.  In
 	// particular, clients with the Barracuda VPN client will see something like:
 	// `192.168.3.51/00ffffff` as their IP address.
@@ -68,7 +70,7 @@ func NewIPv4Addr(ipv4Str string) (IPv4Addr, error) {
 	if err == nil {
 		ipv4 := ipAddr.To4()
 		if ipv4 == nil {
-			return IPv4Addr{}, fmt.Errorf("Unable to convert %s to an IPv4 address", ipv4Str)
+			return IPv4Addr{}, &ParseError{Input: input, Kind: KindFamily, Family: TypeIPv4}
 		}
 
 		// If we see an IPv6 netmask, convert it to an IPv4 mask.
@@ -76,9 +78,9 @@ func NewIPv4Addr(ipv4Str string) (IPv4Addr, error) {
 		if netmaskSepPos != -1 && netmaskSepPos+1 < len(ipv4Str) {
 			netMask, err := strconv.ParseUint(ipv4Str[netmaskSepPos+1:], 10, 8)
 			if err != nil {
-				return IPv4Addr{}, fmt.Errorf("Unable to convert %s to an IPv4 address: unable to parse CIDR netmask: %v", ipv4Str, err)
+				return IPv4Addr{}, &ParseError{Input: input, Kind: KindMask, Family: TypeIPv4, Cause: fmt.Errorf("%w: %v", ErrInvalidMask, err)}
 			} else if netMask > 128 {
-				return IPv4Addr{}, fmt.Errorf("Unable to convert %s to an IPv4 address: invalid CIDR netmask", ipv4Str)
+				return IPv4Addr{}, &ParseError{Input: input, Kind: KindMask, Family: TypeIPv4, Cause: fmt.Errorf("%w %+q", ErrInvalidMask, ipv4Str[netmaskSepPos+1:])}
 			}
 
 			if netMask >= 96 {
@@ -98,7 +100,7 @@ func NewIPv4Addr(ipv4Str string) (IPv4Addr, error) {
 	if err == nil {
 		ipv4 := tcpAddr.IP.To4()
 		if ipv4 == nil {
-			return IPv4Addr{}, fmt.Errorf("Unable to resolve %+q as an IPv4 address", ipv4Str)
+			return IPv4Addr{}, &ParseError{Input: input, Kind: KindFamily, Family: TypeIPv4}
 		}
 
 		ipv4Uint32 := binary.BigEndian.Uint32(ipv4)
@@ -116,7 +118,7 @@ func NewIPv4Addr(ipv4Str string) (IPv4Addr, error) {
 	if ip != nil {
 		ipv4 := ip.To4()
 		if ipv4 == nil {
-			return IPv4Addr{}, fmt.Errorf("Unable to string convert %+q to an IPv4 address", ipv4Str)
+			return IPv4Addr{}, &ParseError{Input: input, Kind: KindFamily, Family: TypeIPv4}
 		}
 
 		ipv4Uint32 := binary.BigEndian.Uint32(ipv4)
@@ -127,7 +129,14 @@ func NewIPv4Addr(ipv4Str string) (IPv4Addr, error) {
 		return ipv4Addr, nil
 	}
 
-	return IPv4Addr{}, fmt.Errorf("Unable to parse %+q to an IPv4 address: %v", ipv4Str, err)
+	// A valid IPv4 address with an invalid prefix length (e.g. `10.0.0.0/33`)
+	if i := strings.LastIndexByte(ipv4Str, '/'); i != -1 {
+		if ip := net.ParseIP(ipv4Str[:i]); ip != nil && ip.To4() != nil {
+			return IPv4Addr{}, &ParseError{Input: input, Kind: KindMask, Family: TypeIPv4, Cause: fmt.Errorf("%w %+q", ErrInvalidMask, ipv4Str[i+1:])}
+		}
+	}
+
+	return IPv4Addr{}, &ParseError{Input: input, Kind: KindSyntax, Family: TypeIPv4, Cause: err}
 }
 
 // AddressBinString returns a string with the IPv4Addr's Address represented
@@ -500,12 +509,24 @@ func ipv4AddrInit() {
 		"size", // Same position as in IPv6 for output consistency
 		"broadcast",
 		"uint32",
+		"reverse_dns",
+		"reverse_zones",
 	}
 
 	ipv4AddrAttrMap = map[AttrName]func(ipv4 IPv4Addr) string{
 		"broadcast": func(ipv4 IPv4Addr) string {
 			return ipv4.Broadcast().String()
 		},
+		"reverse_dns": func(ipv4 IPv4Addr) string {
+			return ipv4.ReverseDNS()
+		},
+		"reverse_zones": func(ipv4 IPv4Addr) string {
+			zones, err := ipv4.ReverseZones()
+			if err != nil {
+				return ""
+			}
+			return strings.Join(zones, " ")
+		},
 		"size": func(ipv4 IPv4Addr) string {
 			return fmt.Sprintf("%d", 1<<uint(IPv4len*8-ipv4.Maskbits()))
 		},
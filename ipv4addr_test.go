@@ -983,7 +983,7 @@ func TestIPv4CmpRFC(t *testing.T) {
 }
 
 func TestIPv4Attrs(t *testing.T) {
-	const expectedNumAttrs = 3
+	const expectedNumAttrs = 5
 	attrs := sockaddr.IPv4Attrs()
 	if len(attrs) != expectedNumAttrs {
 		t.Fatalf("wrong number of IPv4Attrs: %d vs %d", len(attrs), expectedNumAttrs)
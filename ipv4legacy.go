@@ -0,0 +1,131 @@
+package sockaddr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseIPv4Legacy parses an IPv4 address written in any of the forms accepted
+// by inet_aton(3), as found in old configuration and `/etc/hosts` files, and
+// normalizes it to an IPv4Addr:
+//
+//   - `a.b.c.d`: four 8-bit parts (e.g. `10.0.0.1`)
+//   - `a.b.c`: the last part is 16 bits (e.g. `10.0.1` is `10.0.0.1`)
+//   - `a.b`: the last part is 24 bits (e.g. `10.1` is `10.0.0.1`)
+//   - `a`: a single 32-bit integer (e.g. `167772161` is `10.0.0.1`)
+//
+// Each part may be decimal, hexadecimal with a `0x` prefix (e.g.
+// `0x0a000001`) or octal with a leading zero (e.g. `012.0.0.1` is
+// `10.0.0.1`).  The address may be followed by a decimal prefix length (e.g.
+// `10.1/8`) or a port (e.g. `10.1:80`).
+//
+// Octal parts whose value differs from their decimal reading (e.g. `012`) are
+// a common source of mistakes, so a warning wrapping ErrAmbiguousOctal is
+// returned for each of them.  The address is still parsed as octal.
+//
+// NewIPv4Addr() does not accept these forms, see ParseOptions.LegacyIPv4 to
+// accept them in ParseSockAddr() and ParseIPAddr().
+func ParseIPv4Legacy(s string) (ipv4 IPv4Addr, warnings []error, err error) {
+	addrStr, prefixLen, port := s, IPv4len*8, IPPort(0)
+	if i := strings.LastIndexByte(addrStr, ':'); i != -1 {
+		p, err := strconv.ParseUint(addrStr[i+1:], 10, 16)
+		if err != nil {
+			return IPv4Addr{}, nil, &ParseError{Input: s, Kind: KindPort, Family: TypeIPv4, Cause: fmt.Errorf("invalid port %+q", addrStr[i+1:])}
+		}
+		addrStr, port = addrStr[:i], IPPort(p)
+	} else if i := strings.LastIndexByte(addrStr, '/'); i != -1 {
+		n, err := strconv.ParseUint(addrStr[i+1:], 10, 8)
+		if err != nil || n > IPv4len*8 {
+			return IPv4Addr{}, nil, &ParseError{Input: s, Kind: KindMask, Family: TypeIPv4, Cause: fmt.Errorf("%w: prefix length %+q must be between 0 and %d", ErrInvalidMask, addrStr[i+1:], IPv4len*8)}
+		}
+		addrStr, prefixLen = addrStr[:i], int(n)
+	}
+
+	parts := strings.Split(addrStr, ".")
+	if len(parts) > IPv4len {
+		return IPv4Addr{}, nil, &ParseError{Input: s, Kind: KindSyntax, Family: TypeIPv4, Cause: errors.New("too many parts")}
+	}
+
+	var address uint64
+	for i, part := range parts {
+		// The last part fills the remaining bits of the address.
+		bits := 8
+		if i == len(parts)-1 {
+			bits = 8 * (IPv4len - i)
+		}
+
+		v, warning, err := parseIPv4LegacyPart(part)
+		if err != nil {
+			return IPv4Addr{}, nil, &ParseError{Input: s, Kind: KindSyntax, Family: TypeIPv4, Cause: err}
+		}
+		if v >= 1<<uint(bits) {
+			return IPv4Addr{}, nil, &ParseError{Input: s, Kind: KindSyntax, Family: TypeIPv4, Cause: fmt.Errorf("part %+q does not fit in %d bits", part, bits)}
+		}
+		if warning != nil {
+			warnings = append(warnings, warning)
+		}
+
+		address = address<<uint(bits) | v
+	}
+
+	return IPv4Addr{
+		Address: IPv4Address(address),
+		Mask:    IPv4Mask(ipv4HostMaskLen(prefixLen)),
+		Port:    port,
+	}, warnings, nil
+}
+
+// checkStrictIPv4Legacy returns an error if s, parsed by ParseIPv4Legacy()
+// with warnings, contains an ambiguous octal part or a zero-padded prefix
+// length or port.
+func checkStrictIPv4Legacy(s string, warnings []error) error {
+	if len(warnings) > 0 {
+		return &ParseError{Input: s, Kind: KindSyntax, Family: TypeIPv4, Cause: errors.Join(warnings...)}
+	}
+
+	if i := strings.LastIndexByte(s, ':'); i != -1 {
+		if hasLeadingZero(s[i+1:]) {
+			return &ParseError{Input: s, Kind: KindPort, Family: TypeIPv4, Cause: fmt.Errorf("zero-padded port %+q", s[i+1:])}
+		}
+	} else if i := strings.LastIndexByte(s, '/'); i != -1 {
+		if hasLeadingZero(s[i+1:]) {
+			return &ParseError{Input: s, Kind: KindMask, Family: TypeIPv4, Cause: fmt.Errorf("%w: zero-padded prefix length %+q", ErrInvalidMask, s[i+1:])}
+		}
+	}
+
+	return nil
+}
+
+// parseIPv4LegacyPart returns the value of a decimal, hexadecimal or octal
+// part of a legacy IPv4 address, and a warning if the part is an ambiguous
+// octal number.
+func parseIPv4LegacyPart(part string) (v uint64, warning error, err error) {
+	switch {
+	case part == "":
+		return 0, nil, errors.New("empty part")
+	case strings.HasPrefix(part, "0x"), strings.HasPrefix(part, "0X"):
+		v, err = strconv.ParseUint(part[2:], 16, 32)
+		if err != nil || len(part) == 2 {
+			return 0, nil, fmt.Errorf("invalid hexadecimal part %+q", part)
+		}
+		return v, nil, nil
+	case len(part) > 1 && part[0] == '0':
+		v, err = strconv.ParseUint(part[1:], 8, 32)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid octal part %+q", part)
+		}
+
+		if d, _ := strconv.ParseUint(part, 10, 32); d != v {
+			warning = fmt.Errorf("%w: %+q is %d, not %d", ErrAmbiguousOctal, part, v, d)
+		}
+		return v, warning, nil
+	default:
+		v, err = strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid part %+q", part)
+		}
+		return v, nil, nil
+	}
+}
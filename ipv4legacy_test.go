@@ -0,0 +1,159 @@
+package sockaddr_test
+
+import (
+	"errors"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestParseIPv4Legacy(t *testing.T) {
+	tests := []struct {
+		input    string
+		output   string
+		warnings int
+		kind     sockaddr.ParseErrorKind
+		fail     bool
+	}{
+		{input: "10.0.0.1", output: "10.0.0.1"},
+		{input: "10.1", output: "10.0.0.1"},
+		{input: "10.0.1", output: "10.0.0.1"},
+		{input: "10.65535", output: "10.0.255.255"},
+		{input: "127.1", output: "127.0.0.1"},
+		{input: "167772161", output: "10.0.0.1"},
+		{input: "0", output: "0.0.0.0"},
+		{input: "4294967295", output: "255.255.255.255"},
+		{input: "0x0a000001", output: "10.0.0.1"},
+		{input: "0X0A.0x0.0x0.0x1", output: "10.0.0.1"},
+		{input: "012.0.0.1", output: "10.0.0.1", warnings: 1},
+		{input: "012.010.0.01", output: "10.8.0.1", warnings: 2},
+		{input: "00.0.0.07", output: "0.0.0.7"},
+		{input: "0300.0250.0.1", output: "192.168.0.1", warnings: 2},
+		{input: "10.1/8", output: "10.0.0.1/8"},
+		{input: "0x0a000001:8080", output: "10.0.0.1:8080"},
+		{input: "", kind: sockaddr.KindSyntax, fail: true},
+		{input: "10..1", kind: sockaddr.KindSyntax, fail: true},
+		{input: "1.2.3.4.5", kind: sockaddr.KindSyntax, fail: true},
+		{input: "256.0.0.1", kind: sockaddr.KindSyntax, fail: true},
+		{input: "10.16777216", kind: sockaddr.KindSyntax, fail: true},
+		{input: "4294967296", kind: sockaddr.KindSyntax, fail: true},
+		{input: "08.0.0.1", kind: sockaddr.KindSyntax, fail: true},
+		{input: "0x", kind: sockaddr.KindSyntax, fail: true},
+		{input: "0xg", kind: sockaddr.KindSyntax, fail: true},
+		{input: "-1", kind: sockaddr.KindSyntax, fail: true},
+		{input: "10.1/33", kind: sockaddr.KindMask, fail: true},
+		{input: "10.1:65536", kind: sockaddr.KindPort, fail: true},
+		{input: "2001:db8::1", kind: sockaddr.KindSyntax, fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ipv4, warnings, err := sockaddr.ParseIPv4Legacy(test.input)
+			if test.fail {
+				var pe *sockaddr.ParseError
+				if !errors.As(err, &pe) {
+					t.Fatalf("expected %q to fail with a ParseError, got %v (%v)", test.input, ipv4, err)
+				}
+				if pe.Kind != test.kind {
+					t.Fatalf("wrong error kind: %s vs %s: %v", pe.Kind, test.kind, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if ipv4.String() != test.output {
+				t.Errorf("expected %q, received %q", test.output, ipv4)
+			}
+
+			if len(warnings) != test.warnings {
+				t.Fatalf("expected %d warnings, received %v", test.warnings, warnings)
+			}
+			for _, warning := range warnings {
+				if !errors.Is(warning, sockaddr.ErrAmbiguousOctal) {
+					t.Errorf("expected ErrAmbiguousOctal, received %v", warning)
+				}
+			}
+		})
+	}
+}
+
+func TestParseOptions_LegacyIPv4(t *testing.T) {
+	tests := []struct {
+		input  string
+		output string
+	}{
+		{"10.1", "10.0.0.1"},
+		{"0x0a000001", "10.0.0.1"},
+		{"012.0.0.1", "10.0.0.1"},
+		{"167772161:80", "10.0.0.1:80"},
+		{"10.0.0.0/8", "10.0.0.0/8"},
+		{"2001:db8::1", "2001:db8::1"},
+		{"192.168.3.51/00ffffff", "192.168.3.51"},
+	}
+
+	for _, test := range tests {
+		sa, err := sockaddr.ParseSockAddr(test.input, sockaddr.ParseOptions{LegacyIPv4: true})
+		if err != nil {
+			t.Errorf("ParseSockAddr(%q): %v", test.input, err)
+			continue
+		}
+		if sa.String() != test.output {
+			t.Errorf("ParseSockAddr(%q): expected %q, received %q", test.input, test.output, sa)
+		}
+	}
+
+	// The default parsers do not accept the legacy forms.
+	for _, input := range []string{"10.1", "0x0a000001", "167772161"} {
+		if sa, err := sockaddr.ParseIPAddr(input, sockaddr.ParseOptions{}); err == nil {
+			t.Errorf("expected ParseIPAddr(%q) to fail without LegacyIPv4, received %v", input, sa)
+		}
+		if ipv4, err := sockaddr.NewIPv4Addr(input); err == nil {
+			t.Errorf("expected NewIPv4Addr(%q) to fail, received %v", input, ipv4)
+		}
+	}
+}
+
+func TestParseOptions_LegacyIPv4Strict(t *testing.T) {
+	opts := sockaddr.ParseOptions{LegacyIPv4: true, Strict: true}
+
+	tests := []struct {
+		input  string
+		output string
+		want   error
+	}{
+		{input: "10.1", output: "10.0.0.1"},
+		{input: "0x0a000001:80", output: "10.0.0.1:80"},
+		{input: "00.0.0.1", output: "0.0.0.1"},
+		{input: "10.0.0.0/8", output: "10.0.0.0/8"},
+		{input: "012.0.0.1", want: sockaddr.ErrAmbiguousOctal},
+		{input: "10.0.0.010", want: sockaddr.ErrAmbiguousOctal},
+		{input: "10.0.0.0/08", want: sockaddr.ErrInvalidMask},
+		{input: "10.0.0.1:080"},
+	}
+
+	for _, test := range tests {
+		ipAddr, err := sockaddr.ParseIPAddr(test.input, opts)
+		if test.output == "" {
+			var pe *sockaddr.ParseError
+			if !errors.As(err, &pe) {
+				t.Errorf("expected ParseIPAddr(%q) to fail with a ParseError, received %v (%v)", test.input, ipAddr, err)
+			}
+			if test.want != nil && !errors.Is(err, test.want) {
+				t.Errorf("expected ParseIPAddr(%q) to wrap %v, received %v", test.input, test.want, err)
+			}
+			if sa, err := sockaddr.ParseSockAddr(test.input, opts); err == nil {
+				t.Errorf("expected ParseSockAddr(%q) to fail, received %v", test.input, sa)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseIPAddr(%q): %v", test.input, err)
+			continue
+		}
+		if ipAddr.String() != test.output {
+			t.Errorf("ParseIPAddr(%q): expected %q, received %q", test.input, test.output, ipAddr)
+		}
+	}
+}
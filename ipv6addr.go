@@ -1,62 +1,59 @@
 package sockaddr
 
 import (
-	"bytes"
-	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 	"net"
+	"strings"
 )
 
+// NOTE: Prior to the introduction of Uint128, IPv6Address, IPv6Network, and
+// IPv6Mask were defined as a *big.Int.  Callers migrating from the *big.Int
+// representation should use the deprecated IPv6AddressFromBigInt(),
+// IPv6NetworkFromBigInt(), IPv6MaskFromBigInt(), and BigInt() helpers below,
+// or convert to and from a Uint128 directly.
 type (
 	// IPv6Address is a named type representing an IPv6 address.
-	IPv6Address *big.Int
+	IPv6Address Uint128
 
 	// IPv6Network is a named type representing an IPv6 network.
-	IPv6Network *big.Int
+	IPv6Network Uint128
 
 	// IPv6Mask is a named type representing an IPv6 network mask.
-	IPv6Mask *big.Int
+	IPv6Mask Uint128
 )
 
 // IPv6HostPrefix is a constant represents a /128 IPv6 Prefix.
 const IPv6HostPrefix = IPPrefixLen(128)
 
-// ipv6HostMask is an unexported big.Int representing a /128 IPv6 address.
+// ipv6HostMask is an unexported Uint128 representing a /128 IPv6 address.
 // This value must be a constant and always set to all ones.
-var ipv6HostMask IPv6Mask
+var ipv6HostMask = IPv6Mask(uint128Mask(128))
 
 // ipv6AddrAttrMap is a map of the IPv6Addr type-specific attributes.
 var ipv6AddrAttrMap map[AttrName]func(IPv6Addr) string
 var ipv6AddrAttrs []AttrName
 
 func init() {
-	biMask := new(big.Int)
-	biMask.SetBytes([]byte{
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-	},
-	)
-	ipv6HostMask = IPv6Mask(biMask)
-
 	ipv6AddrInit()
 }
 
 // IPv6Addr implements a convenience wrapper around the union of Go's
 // built-in net.IP and net.IPNet types.  In UNIX-speak, IPv6Addr implements
 // `sockaddr` when the the address family is set to AF_INET6
-// (i.e. `sockaddr_in6`).
+// (i.e. `sockaddr_in6`).  IPv6Addr is a value type and is safe to copy and
+// compare.
 type IPv6Addr struct {
 	IPAddr
 	Address IPv6Address
 	Mask    IPv6Mask
 	Port    IPPort
+
+	// Zone is the IPv6 scoped addressing zone (e.g. the interface name
+	// `eth0` in `fe80::1%eth0`).  Zone is empty for addresses that are not
+	// scoped.
+	Zone string
 }
 
 // NewIPv6Addr creates an IPv6Addr from a string.  String can be in the form of
@@ -65,6 +62,9 @@ type IPv6Addr struct {
 // with a `/128` mask), an IPv6 CIDR (e.g. `2001:4860:0:2001::68/64`, which has
 // its IP port initialized to zero).  ipv6Str can not be a hostname.
 //
+// Scoped addresses may include a zone after the address in any of the above
+// forms (e.g. `fe80::1%eth0`, `[fe80::1%eth0]:8080`, or `fe80::1%eth0/64`).
+//
 // NOTE: Many net.*() routines will initialize and return an IPv4 address.
 // Always test to make sure the address returned cannot be converted to a 4 byte
 // array using To4().
@@ -82,7 +82,7 @@ LOOP:
 	}
 
 	if !v6Addr {
-		return IPv6Addr{}, fmt.Errorf("Unable to resolve %+q as an IPv6 address, appears to be an IPv4 address", ipv6Str)
+		return IPv6Addr{}, &ParseError{Input: ipv6Str, Kind: KindFamily, Family: TypeIPv6, Cause: errors.New("appears to be an IPv4 address")}
 	}
 
 	// Attempt to parse ipv6Str as a /128 host with a port number.
@@ -90,44 +90,47 @@ LOOP:
 	if err == nil {
 		ipv6 := tcpAddr.IP.To16()
 		if ipv6 == nil {
-			return IPv6Addr{}, fmt.Errorf("Unable to resolve %+q as a 16byte IPv6 address", ipv6Str)
+			return IPv6Addr{}, &ParseError{Input: ipv6Str, Kind: KindFamily, Family: TypeIPv6}
 		}
 
-		ipv6BigIntAddr := new(big.Int)
-		ipv6BigIntAddr.SetBytes(ipv6)
-
-		ipv6BigIntMask := new(big.Int)
-		ipv6BigIntMask.Set(ipv6HostMask)
-
 		ipv6Addr := IPv6Addr{
-			Address: IPv6Address(ipv6BigIntAddr),
-			Mask:    IPv6Mask(ipv6BigIntMask),
+			Address: IPv6Address(netIPToUint128(ipv6)),
+			Mask:    ipv6HostMask,
 			Port:    IPPort(tcpAddr.Port),
+			Zone:    tcpAddr.Zone,
 		}
 
 		return ipv6Addr, nil
 	}
 
+	// net.ResolveTCPAddr() refuses IPv4-mapped addresses for "tcp6".
+	if ipv6Addr, ok := parseMappedHostPort(ipv6Str); ok {
+		return ipv6Addr, nil
+	}
+
+	input := ipv6Str
+
 	// Parse as a naked IPv6 address.  Trim square brackets if present.
 	if len(ipv6Str) > 2 && ipv6Str[0] == '[' && ipv6Str[len(ipv6Str)-1] == ']' {
 		ipv6Str = ipv6Str[1 : len(ipv6Str)-1]
 	}
+
+	ipv6Str, zone, err := splitIPv6Zone(ipv6Str)
+	if err != nil {
+		return IPv6Addr{}, &ParseError{Input: input, Kind: KindZone, Family: TypeIPv6, Cause: err}
+	}
+
 	ip := net.ParseIP(ipv6Str)
 	if ip != nil {
 		ipv6 := ip.To16()
 		if ipv6 == nil {
-			return IPv6Addr{}, fmt.Errorf("Unable to string convert %+q to a 16byte IPv6 address", ipv6Str)
+			return IPv6Addr{}, &ParseError{Input: input, Kind: KindFamily, Family: TypeIPv6}
 		}
 
-		ipv6BigIntAddr := new(big.Int)
-		ipv6BigIntAddr.SetBytes(ipv6)
-
-		ipv6BigIntMask := new(big.Int)
-		ipv6BigIntMask.Set(ipv6HostMask)
-
 		return IPv6Addr{
-			Address: IPv6Address(ipv6BigIntAddr),
-			Mask:    IPv6Mask(ipv6BigIntMask),
+			Address: IPv6Address(netIPToUint128(ipv6)),
+			Mask:    ipv6HostMask,
+			Zone:    zone,
 		}, nil
 	}
 
@@ -136,39 +139,38 @@ LOOP:
 	if err == nil {
 		ipv6 := ipAddr.To16()
 		if ipv6 == nil {
-			return IPv6Addr{}, fmt.Errorf("Unable to convert %+q to a 16byte IPv6 address", ipv6Str)
+			return IPv6Addr{}, &ParseError{Input: input, Kind: KindFamily, Family: TypeIPv6}
 		}
 
-		ipv6BigIntAddr := new(big.Int)
-		ipv6BigIntAddr.SetBytes(ipv6)
-
-		ipv6BigIntMask := new(big.Int)
-		ipv6BigIntMask.SetBytes(network.Mask)
-
 		ipv6Addr := IPv6Addr{
-			Address: IPv6Address(ipv6BigIntAddr),
-			Mask:    IPv6Mask(ipv6BigIntMask),
+			Address: IPv6Address(netIPToUint128(ipv6)),
+			Mask:    IPv6Mask(netIPToUint128(net.IP(network.Mask))),
+			Zone:    zone,
 		}
 		return ipv6Addr, nil
 	}
 
-	return IPv6Addr{}, fmt.Errorf("Unable to parse %+q to an IPv6 address: %v", ipv6Str, err)
+	// A valid IPv6 address with an invalid prefix length (e.g.
+	// `2001:db8::/129`)
+	if i := strings.LastIndexByte(ipv6Str, '/'); i != -1 && net.ParseIP(ipv6Str[:i]) != nil {
+		return IPv6Addr{}, &ParseError{Input: input, Kind: KindMask, Family: TypeIPv6, Cause: fmt.Errorf("%w %+q", ErrInvalidMask, ipv6Str[i+1:])}
+	}
+
+	return IPv6Addr{}, &ParseError{Input: input, Kind: KindSyntax, Family: TypeIPv6, Cause: err}
 }
 
 // AddressBinString returns a string with the IPv6Addr's Address represented
 // as a sequence of '0' and '1' characters.  This method is useful for
 // debugging or by operators who want to inspect an address.
 func (ipv6 IPv6Addr) AddressBinString() string {
-	bi := big.Int(*ipv6.Address)
-	return fmt.Sprintf("%0128s", bi.Text(2))
+	return fmt.Sprintf("%064b%064b", ipv6.Address.Hi, ipv6.Address.Lo)
 }
 
 // AddressHexString returns a string with the IPv6Addr address represented as
 // a sequence of hex characters.  This method is useful for debugging or by
 // operators who want to inspect an address.
 func (ipv6 IPv6Addr) AddressHexString() string {
-	bi := big.Int(*ipv6.Address)
-	return fmt.Sprintf("%032s", bi.Text(16))
+	return fmt.Sprintf("%016x%016x", ipv6.Address.Hi, ipv6.Address.Lo)
 }
 
 // CmpAddress follows the Cmp() standard protocol and returns:
@@ -183,12 +185,7 @@ func (ipv6 IPv6Addr) CmpAddress(sa SockAddr) int {
 		return sortDeferDecision
 	}
 
-	ipv6aBigInt := new(big.Int)
-	ipv6aBigInt.Set(ipv6.Address)
-	ipv6bBigInt := new(big.Int)
-	ipv6bBigInt.Set(ipv6b.Address)
-
-	return ipv6aBigInt.Cmp(ipv6bBigInt)
+	return Uint128(ipv6.Address).Cmp(Uint128(ipv6b.Address))
 }
 
 // CmpPort follows the Cmp() standard protocol and returns:
@@ -265,48 +262,21 @@ func (ipv6 IPv6Addr) Contains(sa SockAddr) bool {
 // ContainsAddress returns true if the IPv6Address is contained within the
 // receiver.
 func (ipv6 IPv6Addr) ContainsAddress(x IPv6Address) bool {
-	xAddr := IPv6Addr{
-		Address: x,
-		Mask:    ipv6HostMask,
-	}
-
-	{
-		xIPv6 := xAddr.FirstUsable().(IPv6Addr)
-		yIPv6 := ipv6.FirstUsable().(IPv6Addr)
-		if xIPv6.CmpAddress(yIPv6) >= 1 {
-			return false
-		}
-	}
-
-	{
-		xIPv6 := xAddr.LastUsable().(IPv6Addr)
-		yIPv6 := ipv6.LastUsable().(IPv6Addr)
-		if xIPv6.CmpAddress(yIPv6) <= -1 {
-			return false
-		}
-	}
-	return true
+	return Uint128(ipv6.NetworkAddress()).Cmp(Uint128(x)) <= 0 &&
+		ipv6.lastAddress().Cmp(Uint128(x)) >= 0
 }
 
 // ContainsNetwork returns true if the network from IPv6Addr is contained within
-// the receiver.
+// the receiver.  If the receiver has a Zone, the argument must have the same
+// Zone in order to be contained.  A receiver without a Zone contains matching
+// networks from any zone.
 func (x IPv6Addr) ContainsNetwork(y IPv6Addr) bool {
-	{
-		xIPv6 := x.FirstUsable().(IPv6Addr)
-		yIPv6 := y.FirstUsable().(IPv6Addr)
-		if ret := xIPv6.CmpAddress(yIPv6); ret >= 1 {
-			return false
-		}
+	if x.Zone != "" && x.Zone != y.Zone {
+		return false
 	}
 
-	{
-		xIPv6 := x.LastUsable().(IPv6Addr)
-		yIPv6 := y.LastUsable().(IPv6Addr)
-		if ret := xIPv6.CmpAddress(yIPv6); ret <= -1 {
-			return false
-		}
-	}
-	return true
+	return Uint128(x.NetworkAddress()).Cmp(Uint128(y.NetworkAddress())) <= 0 &&
+		x.lastAddress().Cmp(y.lastAddress()) >= 0
 }
 
 // DialPacketArgs returns the arguments required to be passed to
@@ -314,11 +284,10 @@ func (x IPv6Addr) ContainsNetwork(y IPv6Addr) bool {
 // DialPacketArgs() will fail.  See Host() to create an IPv6Addr with its
 // mask set to /128.
 func (ipv6 IPv6Addr) DialPacketArgs() (network, dialArgs string) {
-	ipv6Mask := big.Int(*ipv6.Mask)
-	if ipv6Mask.Cmp(ipv6HostMask) != 0 || ipv6.Port == 0 {
+	if ipv6.Mask != ipv6HostMask || ipv6.Port == 0 {
 		return "udp6", ""
 	}
-	return "udp6", fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+	return "udp6", fmt.Sprintf("[%s]:%d", ipv6.zonedIPString(), ipv6.Port)
 }
 
 // DialStreamArgs returns the arguments required to be passed to
@@ -326,11 +295,10 @@ func (ipv6 IPv6Addr) DialPacketArgs() (network, dialArgs string) {
 // DialStreamArgs() will fail.  See Host() to create an IPv6Addr with its
 // mask set to /128.
 func (ipv6 IPv6Addr) DialStreamArgs() (network, dialArgs string) {
-	ipv6Mask := big.Int(*ipv6.Mask)
-	if ipv6Mask.Cmp(ipv6HostMask) != 0 || ipv6.Port == 0 {
+	if ipv6.Mask != ipv6HostMask || ipv6.Port == 0 {
 		return "tcp6", ""
 	}
-	return "tcp6", fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+	return "tcp6", fmt.Sprintf("[%s]:%d", ipv6.zonedIPString(), ipv6.Port)
 }
 
 // Equal returns true if a SockAddr is equal to the receiving IPv4Addr.
@@ -340,11 +308,11 @@ func (ipv6a IPv6Addr) Equal(sa SockAddr) bool {
 		return false
 	}
 
-	if ipv6a.NetIP().String() != ipv6b.NetIP().String() {
+	if ipv6a.Address != ipv6b.Address {
 		return false
 	}
 
-	if ipv6a.NetIPNet().String() != ipv6b.NetIPNet().String() {
+	if ipv6a.Mask != ipv6b.Mask {
 		return false
 	}
 
@@ -352,6 +320,10 @@ func (ipv6a IPv6Addr) Equal(sa SockAddr) bool {
 		return false
 	}
 
+	if ipv6a.Zone != ipv6b.Zone {
+		return false
+	}
+
 	return true
 }
 
@@ -366,6 +338,7 @@ func (ipv6 IPv6Addr) FirstUsable() IPAddr {
 	return IPv6Addr{
 		Address: IPv6Address(ipv6.NetworkAddress()),
 		Mask:    ipv6HostMask,
+		Zone:    ipv6.Zone,
 	}
 }
 
@@ -378,6 +351,7 @@ func (ipv6 IPv6Addr) Host() IPAddr {
 		Address: ipv6.Address,
 		Mask:    ipv6HostMask,
 		Port:    ipv6.Port,
+		Zone:    ipv6.Zone,
 	}
 }
 
@@ -388,22 +362,10 @@ func (ipv6 IPv6Addr) IPPort() IPPort {
 
 // LastUsable returns the last address in a given network.
 func (ipv6 IPv6Addr) LastUsable() IPAddr {
-	addr := new(big.Int)
-	addr.Set(ipv6.Address)
-
-	mask := new(big.Int)
-	mask.Set(ipv6.Mask)
-
-	negMask := new(big.Int)
-	negMask.Xor(ipv6HostMask, mask)
-
-	lastAddr := new(big.Int)
-	lastAddr.And(addr, mask)
-	lastAddr.Or(lastAddr, negMask)
-
 	return IPv6Addr{
-		Address: IPv6Address(lastAddr),
+		Address: IPv6Address(ipv6.lastAddress()),
 		Mask:    ipv6HostMask,
+		Zone:    ipv6.Zone,
 	}
 }
 
@@ -411,28 +373,31 @@ func (ipv6 IPv6Addr) LastUsable() IPAddr {
 // net.ListenUDP().  If the Mask of ipv6 is not a /128, ListenPacketArgs()
 // will fail.  See Host() to create an IPv6Addr with its mask set to /128.
 func (ipv6 IPv6Addr) ListenPacketArgs() (network, listenArgs string) {
-	ipv6Mask := big.Int(*ipv6.Mask)
-	if ipv6Mask.Cmp(ipv6HostMask) != 0 {
+	if ipv6.Mask != ipv6HostMask {
 		return "udp6", ""
 	}
-	return "udp6", fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+	return "udp6", fmt.Sprintf("[%s]:%d", ipv6.zonedIPString(), ipv6.Port)
 }
 
 // ListenStreamArgs returns the arguments required to be passed to
 // net.ListenTCP().  If the Mask of ipv6 is not a /128, ListenStreamArgs()
 // will fail.  See Host() to create an IPv6Addr with its mask set to /128.
 func (ipv6 IPv6Addr) ListenStreamArgs() (network, listenArgs string) {
-	ipv6Mask := big.Int(*ipv6.Mask)
-	if ipv6Mask.Cmp(ipv6HostMask) != 0 {
+	if ipv6.Mask != ipv6HostMask {
 		return "tcp6", ""
 	}
-	return "tcp6", fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+	return "tcp6", fmt.Sprintf("[%s]:%d", ipv6.zonedIPString(), ipv6.Port)
 }
 
 // Maskbits returns the number of network mask bits in a given IPv6Addr.  For
 // example, the Maskbits() of "2001:0db8::0003/64" would return 64.
 func (ipv6 IPv6Addr) Maskbits() int {
-	maskOnes, _ := ipv6.NetIPNet().Mask.Size()
+	// Non-canonical masks (i.e. masks where the ones are not contiguous)
+	// have no prefix length, mirroring net.IPMask.Size().
+	maskOnes := Uint128(ipv6.Mask).Not().LeadingZeros()
+	if uint128Mask(maskOnes) != Uint128(ipv6.Mask) {
+		return 0
+	}
 
 	return maskOnes
 }
@@ -449,14 +414,17 @@ func MustIPv6Addr(addr string) IPv6Addr {
 
 // NetIP returns the address as a net.IP.
 func (ipv6 IPv6Addr) NetIP() *net.IP {
-	return bigIntToNetIPv6(ipv6.Address)
+	b := Uint128(ipv6.Address).Bytes()
+	x := make(net.IP, IPv6len)
+	copy(x, b[:])
+	return &x
 }
 
 // NetIPMask create a new net.IPMask from the IPv6Addr.
 func (ipv6 IPv6Addr) NetIPMask() *net.IPMask {
+	b := Uint128(ipv6.Mask).Bytes()
 	ipv6Mask := make(net.IPMask, IPv6len)
-	m := big.Int(*ipv6.Mask)
-	copy(ipv6Mask, m.Bytes())
+	copy(ipv6Mask, b[:])
 	return &ipv6Mask
 }
 
@@ -474,28 +442,20 @@ func (ipv6 IPv6Addr) Network() IPAddr {
 	return IPv6Addr{
 		Address: IPv6Address(ipv6.NetworkAddress()),
 		Mask:    ipv6.Mask,
+		Zone:    ipv6.Zone,
 	}
 }
 
 // NetworkAddress returns an IPv6Network of the IPv6Addr's network address.
 func (ipv6 IPv6Addr) NetworkAddress() IPv6Network {
-	addr := new(big.Int)
-	addr.SetBytes((*ipv6.Address).Bytes())
-
-	mask := new(big.Int)
-	mask.SetBytes(*ipv6.NetIPMask())
-
-	netAddr := new(big.Int)
-	netAddr.And(addr, mask)
-
-	return IPv6Network(netAddr)
+	return IPv6Network(Uint128(ipv6.Address).And(Uint128(ipv6.Mask)))
 }
 
 // Octets returns a slice of the 16 octets in an IPv6Addr's Address.  The
 // order of the bytes is big endian.
 func (ipv6 IPv6Addr) Octets() []int {
 	x := make([]int, IPv6len)
-	for i, b := range *bigIntToNetIPv6(ipv6.Address) {
+	for i, b := range Uint128(ipv6.Address).Bytes() {
 		x[i] = int(b)
 	}
 
@@ -505,14 +465,14 @@ func (ipv6 IPv6Addr) Octets() []int {
 // String returns a string representation of the IPv6Addr
 func (ipv6 IPv6Addr) String() string {
 	if ipv6.Port != 0 {
-		return fmt.Sprintf("[%s]:%d", ipv6.NetIP().String(), ipv6.Port)
+		return fmt.Sprintf("[%s]:%d", ipv6.zonedIPString(), ipv6.Port)
 	}
 
 	if ipv6.Maskbits() == 128 {
-		return ipv6.NetIP().String()
+		return ipv6.zonedIPString()
 	}
 
-	return fmt.Sprintf("%s/%d", ipv6.NetIP().String(), ipv6.Maskbits())
+	return fmt.Sprintf("%s/%d", ipv6.zonedIPString(), ipv6.Maskbits())
 }
 
 // Type is used as a type switch and returns TypeIPv6
@@ -542,50 +502,190 @@ func ipv6AddrInit() {
 	ipv6AddrAttrs = []AttrName{
 		"size", // Same position as in IPv6 for output consistency
 		"uint128",
+		"zone",
+		"reverse_dns",
+		"reverse_zones",
+		"mac_from_eui64",
+		"canonical",
+		"expanded",
+		"mixed",
+		"embedded_ipv4",
+		"teredo_server",
+		"teredo_client",
+		"teredo_port",
+		"teredo_flags",
 	}
 
 	ipv6AddrAttrMap = map[AttrName]func(ipv6 IPv6Addr) string{
+		"canonical": func(ipv6 IPv6Addr) string {
+			return ipv6.formatAddress(IPv6StyleCanonical)
+		},
+		"embedded_ipv4": func(ipv6 IPv6Addr) string {
+			ipv4, err := ipv6.EmbeddedIPv4()
+			if err != nil {
+				return ""
+			}
+			return ipv4.String()
+		},
+		"expanded": func(ipv6 IPv6Addr) string {
+			return ipv6.formatAddress(IPv6StyleExpanded)
+		},
+		"mixed": func(ipv6 IPv6Addr) string {
+			return ipv6.formatAddress(IPv6StyleMixed)
+		},
+		"mac_from_eui64": func(ipv6 IPv6Addr) string {
+			mac, err := ipv6.MAC()
+			if err != nil {
+				return ""
+			}
+			return mac.String()
+		},
+		"reverse_dns": func(ipv6 IPv6Addr) string {
+			return ipv6.ReverseDNS()
+		},
+		"reverse_zones": func(ipv6 IPv6Addr) string {
+			zones, err := ipv6.ReverseZones()
+			if err != nil {
+				return ""
+			}
+			return strings.Join(zones, " ")
+		},
 		"size": func(ipv6 IPv6Addr) string {
 			netSize := big.NewInt(1)
 			netSize = netSize.Lsh(netSize, uint(IPv6len*8-ipv6.Maskbits()))
 			return netSize.Text(10)
 		},
+		"teredo_client": func(ipv6 IPv6Addr) string {
+			teredo, err := ipv6.Teredo()
+			if err != nil {
+				return ""
+			}
+			return teredo.Client.String()
+		},
+		"teredo_flags": func(ipv6 IPv6Addr) string {
+			teredo, err := ipv6.Teredo()
+			if err != nil {
+				return ""
+			}
+			return fmt.Sprintf("0x%04x", teredo.Flags)
+		},
+		"teredo_port": func(ipv6 IPv6Addr) string {
+			teredo, err := ipv6.Teredo()
+			if err != nil {
+				return ""
+			}
+			return fmt.Sprintf("%d", teredo.Port)
+		},
+		"teredo_server": func(ipv6 IPv6Addr) string {
+			teredo, err := ipv6.Teredo()
+			if err != nil {
+				return ""
+			}
+			return teredo.Server.String()
+		},
 		"uint128": func(ipv6 IPv6Addr) string {
-			b := big.Int(*ipv6.Address)
-			return b.Text(10)
+			return Uint128(ipv6.Address).String()
+		},
+		"zone": func(ipv6 IPv6Addr) string {
+			return ipv6.Zone
 		},
 	}
 }
 
-// bigIntToNetIPv6 is a helper function that correctly returns a net.IP with the
-// correctly padded values.
-func bigIntToNetIPv6(bi *big.Int) *net.IP {
-	x := make(net.IP, IPv6len)
-	ipv6Bytes := bi.Bytes()
+// zonedIPString returns the address of the receiver as a string, followed by
+// its Zone, if any (e.g. `fe80::1%eth0`).  IPv4-mapped addresses retain their
+// `::ffff:` prefix so they are not mistaken for an IPv4 address.
+func (ipv6 IPv6Addr) zonedIPString() string {
+	ipStr := ipv6.NetIP().String()
+	if ipv6.IsMapped() {
+		ipStr = "::ffff:" + ipStr
+	}
 
-	// It's possibe for ipv6Bytes to be less than IPv6len bytes in size.  If
-	// they are different sizes we to pad the size of response.
-	if len(ipv6Bytes) < IPv6len {
-		buf := new(bytes.Buffer)
-		buf.Grow(IPv6len)
+	if ipv6.Zone == "" {
+		return ipStr
+	}
 
-		for i := len(ipv6Bytes); i < IPv6len; i++ {
-			if err := binary.Write(buf, binary.BigEndian, byte(0)); err != nil {
-				panic(fmt.Sprintf("Unable to pad byte %d of input %v: %v", i, bi, err))
-			}
-		}
+	return ipStr + "%" + ipv6.Zone
+}
 
-		for _, b := range ipv6Bytes {
-			if err := binary.Write(buf, binary.BigEndian, b); err != nil {
-				panic(fmt.Sprintf("Unable to preserve endianness of input %v: %v", bi, err))
-			}
-		}
+// lastAddress returns the last address in the receiver's network.
+func (ipv6 IPv6Addr) lastAddress() Uint128 {
+	return Uint128(ipv6.Address).Or(Uint128(ipv6.Mask).Not())
+}
 
-		ipv6Bytes = buf.Bytes()
+// splitIPv6Zone removes the zone, if any, from an IPv6 address or CIDR string
+// and returns the remainder along with the zone.  The zone must immediately
+// follow the address and precede the prefix length, if any (e.g.
+// `fe80::1%eth0/64` returns `fe80::1/64` and `eth0`).
+func splitIPv6Zone(ipv6Str string) (addr, zone string, err error) {
+	i := strings.IndexByte(ipv6Str, '%')
+	if i == -1 {
+		return ipv6Str, "", nil
 	}
-	i := copy(x, ipv6Bytes)
-	if i != IPv6len {
-		panic("IPv6 wrong size")
+
+	addr, zone = ipv6Str[:i], ipv6Str[i+1:]
+	if j := strings.IndexByte(zone, '/'); j != -1 {
+		addr, zone = addr+zone[j:], zone[:j]
 	}
-	return &x
+
+	if zone == "" || strings.IndexByte(zone, '%') != -1 {
+		return "", "", fmt.Errorf("invalid zone %+q", zone)
+	}
+
+	return addr, zone, nil
+}
+
+// netIPToUint128 converts a 16 byte net.IP (or net.IPMask) to a Uint128.
+func netIPToUint128(ip net.IP) Uint128 {
+	var b [IPv6len]byte
+	copy(b[:], ip)
+	return Uint128FromBytes(b)
+}
+
+// BigInt returns the IPv6Address as a newly allocated *big.Int.
+//
+// Deprecated: IPv6Address is no longer a *big.Int.  Use Uint128(addr) and
+// its methods instead.
+func (addr IPv6Address) BigInt() *big.Int {
+	return Uint128(addr).BigInt()
+}
+
+// BigInt returns the IPv6Mask as a newly allocated *big.Int.
+//
+// Deprecated: IPv6Mask is no longer a *big.Int.  Use Uint128(mask) and its
+// methods instead.
+func (mask IPv6Mask) BigInt() *big.Int {
+	return Uint128(mask).BigInt()
+}
+
+// BigInt returns the IPv6Network as a newly allocated *big.Int.
+//
+// Deprecated: IPv6Network is no longer a *big.Int.  Use Uint128(network) and
+// its methods instead.
+func (network IPv6Network) BigInt() *big.Int {
+	return Uint128(network).BigInt()
+}
+
+// IPv6AddressFromBigInt converts a *big.Int to an IPv6Address.
+//
+// Deprecated: IPv6Address is no longer a *big.Int.  Use
+// IPv6Address(Uint128FromBigInt(bi)) or construct a Uint128 directly.
+func IPv6AddressFromBigInt(bi *big.Int) IPv6Address {
+	return IPv6Address(Uint128FromBigInt(bi))
+}
+
+// IPv6MaskFromBigInt converts a *big.Int to an IPv6Mask.
+//
+// Deprecated: IPv6Mask is no longer a *big.Int.  Use
+// IPv6Mask(Uint128FromBigInt(bi)) or construct a Uint128 directly.
+func IPv6MaskFromBigInt(bi *big.Int) IPv6Mask {
+	return IPv6Mask(Uint128FromBigInt(bi))
+}
+
+// IPv6NetworkFromBigInt converts a *big.Int to an IPv6Network.
+//
+// Deprecated: IPv6Network is no longer a *big.Int.  Use
+// IPv6Network(Uint128FromBigInt(bi)) or construct a Uint128 directly.
+func IPv6NetworkFromBigInt(bi *big.Int) IPv6Network {
+	return IPv6Network(Uint128FromBigInt(bi))
 }
@@ -2,6 +2,7 @@ package sockaddr_test
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 	"testing"
@@ -9,26 +10,10 @@ import (
 	sockaddr "github.com/hashicorp/go-sockaddr"
 )
 
-// ipv6HostMask is an unexported big.Int representing a /128 IPv6 address
-var ipv6HostMask sockaddr.IPv6Mask
-
-func init() {
-	biMask := big.NewInt(0)
-	biMask = biMask.SetBytes([]byte{
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-		0xff, 0xff,
-	},
-	)
-	ipv6HostMask = sockaddr.IPv6Mask(biMask)
-}
+// ipv6HostMask is an unexported Uint128 representing a /128 IPv6 address
+var ipv6HostMask = sockaddr.IPv6Mask(sockaddr.Uint128{Hi: math.MaxUint64, Lo: math.MaxUint64})
 
-func newIPv6BigInt(t *testing.T, ipv6Str string) *big.Int {
+func newIPv6Uint128(t *testing.T, ipv6Str string) sockaddr.Uint128 {
 	addr := big.NewInt(0)
 	addrStr := strings.Join(strings.Split(ipv6Str, ":"), "")
 	_, ok := addr.SetString(addrStr, 16)
@@ -36,19 +21,19 @@ func newIPv6BigInt(t *testing.T, ipv6Str string) *big.Int {
 		t.Fatalf("Unable to create an IPv6Addr from string %+q", ipv6Str)
 	}
 
-	return addr
+	return sockaddr.Uint128FromBigInt(addr)
 }
 
 func newIPv6Address(t *testing.T, ipv6Str string) sockaddr.IPv6Address {
-	return sockaddr.IPv6Address(newIPv6BigInt(t, ipv6Str))
+	return sockaddr.IPv6Address(newIPv6Uint128(t, ipv6Str))
 }
 
 func newIPv6Mask(t *testing.T, ipv6Str string) sockaddr.IPv6Mask {
-	return sockaddr.IPv6Mask(newIPv6BigInt(t, ipv6Str))
+	return sockaddr.IPv6Mask(newIPv6Uint128(t, ipv6Str))
 }
 
 func newIPv6Network(t *testing.T, ipv6Str string) sockaddr.IPv6Network {
-	return sockaddr.IPv6Network(newIPv6BigInt(t, ipv6Str))
+	return sockaddr.IPv6Network(newIPv6Uint128(t, ipv6Str))
 }
 
 func TestSockAddr_IPv6Addr(t *testing.T) {
@@ -336,9 +321,7 @@ func TestSockAddr_IPv6Addr(t *testing.T) {
 				t.Errorf("[%d] Unable to type assert +%q's Host to IPv6Addr", idx, test.z00_input)
 			}
 
-			hAddressBigInt := big.Int(*h.Address)
-			hMaskBigInt := big.Int(*h.Mask)
-			if hAddressBigInt.Cmp(ipv6.Address) != 0 || hMaskBigInt.Cmp(ipv6HostMask) != 0 || h.Port != ipv6.Port {
+			if h.Address != ipv6.Address || h.Mask != ipv6HostMask || h.Port != ipv6.Port {
 				t.Errorf("[%d] Expected %+q's Host() to return identical IPv6Addr except mask, received %+q", idx, test.z00_input, h.String())
 			}
 
@@ -366,8 +349,8 @@ func TestSockAddr_IPv6Addr(t *testing.T) {
 				t.Errorf("[%d] Expected %+q's address to be %+q, received %+q", idx, test.z00_input, test.z04_NetIPStringOut, s)
 			}
 
-			if hAddressBigInt.Cmp(test.z05_addrInt) != 0 {
-				t.Errorf("[%d] Expected %+q's Address to return %+v, received %+v", idx, test.z00_input, test.z05_addrInt, hAddressBigInt)
+			if h.Address != test.z05_addrInt {
+				t.Errorf("[%d] Expected %+q's Address to return %+v, received %+v", idx, test.z00_input, test.z05_addrInt, h.Address)
 			}
 
 			n, ok := ipv6.Network().(sockaddr.IPv6Addr)
@@ -375,8 +358,7 @@ func TestSockAddr_IPv6Addr(t *testing.T) {
 				t.Errorf("[%d] Unable to type assert +%q's Network to IPv6Addr", idx, test.z00_input)
 			}
 
-			nAddressBigInt := big.Int(*n.Address)
-			if nAddressBigInt.Cmp(test.z06_netInt) != 0 {
+			if sockaddr.IPv6Network(n.Address) != test.z06_netInt {
 				t.Errorf("[%d] Expected %+q's Network to return %+v, received %+v", idx, test.z00_input, test.z06_netInt, n.Address)
 			}
 
@@ -392,14 +374,12 @@ func TestSockAddr_IPv6Addr(t *testing.T) {
 				t.Errorf("[%d] Expected %+q's network to be %+q, received %+q", idx, test.z00_input, test.z09_NetIPNetStringOut, n)
 			}
 
-			ipv6MaskBigInt := big.Int(*ipv6.Mask)
-			if ipv6MaskBigInt.Cmp(test.z10_maskInt) != 0 {
-				t.Errorf("[%d] Expected %+q's Mask to return %+v, received %+v", idx, test.z00_input, test.z10_maskInt, ipv6MaskBigInt)
+			if ipv6.Mask != test.z10_maskInt {
+				t.Errorf("[%d] Expected %+q's Mask to return %+v, received %+v", idx, test.z00_input, test.z10_maskInt, ipv6.Mask)
 			}
 
-			nMaskBigInt := big.Int(*n.Mask)
-			if nMaskBigInt.Cmp(test.z10_maskInt) != 0 {
-				t.Errorf("[%d] Expected %+q's Network's Mask to return %+v, received %+v", idx, test.z00_input, test.z10_maskInt, nMaskBigInt)
+			if n.Mask != test.z10_maskInt {
+				t.Errorf("[%d] Expected %+q's Network's Mask to return %+v, received %+v", idx, test.z00_input, test.z10_maskInt, n.Mask)
 			}
 
 			// Network()'s mask must match the IPv6Addr's Mask
@@ -630,6 +610,25 @@ func TestSockAddr_IPv6Addr_Equal(t *testing.T) {
 			},
 			fail: true,
 		},
+		{
+			name:  "zone equal",
+			input: sockaddr.MustIPv6Addr("fe80::1%eth0"),
+			cases: sockaddr.SockAddrs{
+				sockaddr.MustIPv6Addr("fe80::1%eth0"),
+				sockaddr.MustIPv6Addr("[fe80::1%eth0]"),
+				sockaddr.MustIPv6Addr("fe80::1%eth0/128"),
+			},
+		},
+		{
+			name:  "zone not equal",
+			input: sockaddr.MustIPv6Addr("fe80::1%eth0"),
+			cases: sockaddr.SockAddrs{
+				sockaddr.MustIPv6Addr("fe80::1"),
+				sockaddr.MustIPv6Addr("fe80::1%eth1"),
+				sockaddr.MustIPv6Addr("[fe80::1%eth0]:80"),
+			},
+			fail: true,
+		},
 		{
 			name:  "equal CIDR",
 			input: sockaddr.MustIPv6Addr("2001:4860:0:2001::68/64"),
@@ -668,6 +667,108 @@ func TestSockAddr_IPv6Addr_Equal(t *testing.T) {
 	}
 }
 
+func TestIPv6Addr_Zone(t *testing.T) {
+	tests := []struct {
+		input      string
+		zone       string
+		str        string
+		network    string
+		dialStream string
+		listen     string
+		contains   []string
+		excludes   []string
+		fail       bool
+	}{
+		{
+			input:      "fe80::1%eth0",
+			zone:       "eth0",
+			str:        "fe80::1%eth0",
+			network:    "fe80::1%eth0",
+			dialStream: "",
+			listen:     "[fe80::1%eth0]:0",
+			contains:   []string{"fe80::1%eth0"},
+			excludes:   []string{"fe80::1", "fe80::1%eth1"},
+		},
+		{
+			input:      "[fe80::1%eth0]:8080",
+			zone:       "eth0",
+			str:        "[fe80::1%eth0]:8080",
+			network:    "fe80::1%eth0",
+			dialStream: "[fe80::1%eth0]:8080",
+			listen:     "[fe80::1%eth0]:8080",
+		},
+		{
+			input:    "fe80::1%en0/64",
+			zone:     "en0",
+			str:      "fe80::1%en0/64",
+			network:  "fe80::%en0/64",
+			contains: []string{"fe80::2%en0", "fe80::ffff%en0/112"},
+			excludes: []string{"fe80::2", "fe80::2%en1", "fe80:1::2%en0"},
+		},
+		{
+			input:    "fe80::/10",
+			str:      "fe80::/10",
+			network:  "fe80::/10",
+			contains: []string{"fe80::1", "fe80::1%eth0", "[fe80::1%eth1]:80"},
+		},
+		{
+			input: "fe80::1%",
+			fail:  true,
+		},
+		{
+			input: "fe80::1%eth0%eth1",
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ipv6, err := sockaddr.NewIPv6Addr(test.input)
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected %q to fail", test.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if ipv6.Zone != test.zone {
+				t.Errorf("wrong zone: %q vs %q", ipv6.Zone, test.zone)
+			}
+			if got, _ := sockaddr.Attr(ipv6, "zone"); got != test.zone {
+				t.Errorf("wrong zone attr: %q vs %q", got, test.zone)
+			}
+			if got := ipv6.String(); got != test.str {
+				t.Errorf("wrong string: %q vs %q", got, test.str)
+			}
+			if got := ipv6.Network().String(); got != test.network {
+				t.Errorf("wrong network: %q vs %q", got, test.network)
+			}
+			if _, got := ipv6.DialStreamArgs(); got != test.dialStream {
+				t.Errorf("wrong DialStreamArgs: %q vs %q", got, test.dialStream)
+			}
+			if test.listen != "" {
+				if _, got := ipv6.ListenStreamArgs(); got != test.listen {
+					t.Errorf("wrong ListenStreamArgs: %q vs %q", got, test.listen)
+				}
+			}
+
+			for _, c := range test.contains {
+				if !ipv6.Contains(sockaddr.MustIPv6Addr(c)) {
+					t.Errorf("expected %q to contain %q", test.input, c)
+				}
+			}
+			for _, e := range test.excludes {
+				if ipv6.Contains(sockaddr.MustIPv6Addr(e)) {
+					t.Errorf("expected %q to not contain %q", test.input, e)
+				}
+			}
+		})
+	}
+}
+
 func TestIPv6Addr_CmpRFC(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -717,9 +818,68 @@ func TestIPv6Addr_CmpRFC(t *testing.T) {
 }
 
 func TestIPv6Attrs(t *testing.T) {
-	const expectedNumAttrs = 2
+	const expectedNumAttrs = 14
 	attrs := sockaddr.IPv6Attrs()
 	if len(attrs) != expectedNumAttrs {
 		t.Fatalf("wrong number of IPv6Attrs: %d vs %d", len(attrs), expectedNumAttrs)
 	}
 }
+
+func BenchmarkNewIPv6Addr(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := sockaddr.NewIPv6Addr("2001:db8:dead:beef::1/64"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkIPv6Addr_Contains(b *testing.B) {
+	network := sockaddr.MustIPv6Addr("2001:db8::/32")
+	host := sockaddr.MustIPv6Addr("2001:db8:dead:beef::1")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !network.Contains(host) {
+			b.Fatal("expected network to contain host")
+		}
+	}
+}
+
+func BenchmarkIPv6Addr_NetworkAddress(b *testing.B) {
+	ipv6 := sockaddr.MustIPv6Addr("2001:db8:dead:beef::1/64")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ipv6.NetworkAddress()
+	}
+}
+
+func BenchmarkIPv6Addr_IfAddrMath(b *testing.B) {
+	ifAddr := sockaddr.IfAddr{
+		SockAddr: sockaddr.MustIPv6Addr("2001:db8:dead:beef::1/64"),
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := sockaddr.IfAddrMath("network", "+256", ifAddr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkIPv6Addr_SortIfBy(b *testing.B) {
+	ifAddrs := make(sockaddr.IfAddrs, 0, 256)
+	for i := 0; i < cap(ifAddrs); i++ {
+		ifAddrs = append(ifAddrs, sockaddr.IfAddr{
+			SockAddr: sockaddr.MustIPv6Addr(fmt.Sprintf("2001:db8:%x::%x/%d", (i*7919)%256, i, 48+i%64)),
+		})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := sockaddr.SortIfBy("address,size", ifAddrs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package sockaddr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IPv6Style selects the text representation of an IPv6 address used by
+// IPv6Addr.Format().
+type IPv6Style int
+
+const (
+	// IPv6StyleCanonical is the RFC 5952 recommended form: lowercase
+	// hexadecimal without leading zeros, with the longest run of two or more
+	// zero groups (the first, if tied) compressed to `::` (e.g.
+	// `2001:db8::1`).  IPv4-mapped addresses and addresses within the NAT64
+	// Well-Known Prefix are written in IPv6StyleMixed (RFC 5952, section 5),
+	// e.g. `::ffff:10.1.2.3` or `64:ff9b::192.0.2.1`.
+	IPv6StyleCanonical IPv6Style = iota
+
+	// IPv6StyleExpanded writes all eight groups as four hexadecimal digits
+	// (e.g. `2001:0db8:0000:0000:0000:0000:0000:0001`).
+	IPv6StyleExpanded
+
+	// IPv6StyleMixed writes the last 32 bits of the address as a dotted-quad
+	// IPv4 address and compresses the first 96 bits as in
+	// IPv6StyleCanonical (e.g. `::ffff:10.1.2.3` or `64:ff9b::192.0.2.1`).
+	IPv6StyleMixed
+)
+
+// ParseIPv6Style returns the IPv6Style named by s: `canonical`, `expanded` or
+// `mixed`.
+func ParseIPv6Style(s string) (IPv6Style, error) {
+	switch strings.ToLower(s) {
+	case "canonical":
+		return IPv6StyleCanonical, nil
+	case "expanded":
+		return IPv6StyleExpanded, nil
+	case "mixed":
+		return IPv6StyleMixed, nil
+	default:
+		return 0, fmt.Errorf("Unknown IPv6 style %+q, must be one of canonical, expanded or mixed", s)
+	}
+}
+
+// String returns the name of the IPv6Style.
+func (style IPv6Style) String() string {
+	switch style {
+	case IPv6StyleCanonical:
+		return "canonical"
+	case IPv6StyleExpanded:
+		return "expanded"
+	case IPv6StyleMixed:
+		return "mixed"
+	default:
+		return fmt.Sprintf("IPv6Style(%d)", int(style))
+	}
+}
+
+// Format returns the IPv6Addr in the same form as String(), with its address
+// written in the given style (e.g. `[2001:0db8:0000:0000:0000:0000:0000:0001]:80`
+// for IPv6StyleExpanded).  Unknown styles are treated as IPv6StyleCanonical.
+func (ipv6 IPv6Addr) Format(style IPv6Style) string {
+	addr := ipv6.formatAddress(style)
+	if ipv6.Zone != "" {
+		addr += "%" + ipv6.Zone
+	}
+
+	if ipv6.Port != 0 {
+		return fmt.Sprintf("[%s]:%d", addr, ipv6.Port)
+	}
+
+	if ipv6.Maskbits() == IPv6len*8 {
+		return addr
+	}
+
+	return fmt.Sprintf("%s/%d", addr, ipv6.Maskbits())
+}
+
+// formatAddress returns the address of the IPv6Addr, without its zone, written
+// in the given style.
+func (ipv6 IPv6Addr) formatAddress(style IPv6Style) string {
+	b := Uint128(ipv6.Address).Bytes()
+	var groups [IPv6len / 2]uint16
+	for i := range groups {
+		groups[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+
+	// RFC 5952, section 5 recommends the mixed notation for addresses with
+	// a well-known prefix and an embedded IPv4 address.
+	embedsIPv4 := ipv6.IsMapped() || nat64WellKnownPrefix.ContainsAddress(ipv6.Address)
+
+	switch {
+	case style == IPv6StyleExpanded:
+		strs := make([]string, len(groups))
+		for i, group := range groups {
+			strs[i] = fmt.Sprintf("%04x", group)
+		}
+		return strings.Join(strs, ":")
+	case style == IPv6StyleMixed, embedsIPv4:
+		s := compressIPv6Groups(groups[:6])
+		if !strings.HasSuffix(s, "::") {
+			s += ":"
+		}
+		return fmt.Sprintf("%s%d.%d.%d.%d", s, b[12], b[13], b[14], b[15])
+	default:
+		return compressIPv6Groups(groups[:])
+	}
+}
+
+// compressIPv6Groups returns groups in hexadecimal separated by colons, with
+// the longest run of two or more zero groups (the first, if tied) replaced
+// by `::` (RFC 5952, section 4.2).
+func compressIPv6Groups(groups []uint16) string {
+	runStart, runLen := -1, 1
+	for i := 0; i < len(groups); {
+		if groups[i] != 0 {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(groups) && groups[j] == 0 {
+			j++
+		}
+		if j-i > runLen {
+			runStart, runLen = i, j-i
+		}
+		i = j
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(groups); i++ {
+		if i == runStart {
+			sb.WriteString("::")
+			i += runLen - 1
+			continue
+		}
+
+		if i > 0 && i != runStart+runLen {
+			sb.WriteByte(':')
+		}
+		sb.WriteString(strconv.FormatUint(uint64(groups[i]), 16))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,155 @@
+package sockaddr_test
+
+import (
+	"net"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestIPv6Addr_Format(t *testing.T) {
+	tests := []struct {
+		input     string
+		canonical string
+		expanded  string
+		mixed     string
+	}{
+		{
+			input:     "2001:db8::1",
+			canonical: "2001:db8::1",
+			expanded:  "2001:0db8:0000:0000:0000:0000:0000:0001",
+			mixed:     "2001:db8::0.0.0.1",
+		},
+		{
+			input:     "2001:DB8:0:0:1:0:0:1",
+			canonical: "2001:db8::1:0:0:1",
+			expanded:  "2001:0db8:0000:0000:0001:0000:0000:0001",
+			mixed:     "2001:db8::1:0:0.0.0.1",
+		},
+		{
+			// A single zero group is not compressed.
+			input:     "2001:db8:0:1:1:1:1:1",
+			canonical: "2001:db8:0:1:1:1:1:1",
+			expanded:  "2001:0db8:0000:0001:0001:0001:0001:0001",
+			mixed:     "2001:db8:0:1:1:1:0.1.0.1",
+		},
+		{
+			input:     "::",
+			canonical: "::",
+			expanded:  "0000:0000:0000:0000:0000:0000:0000:0000",
+			mixed:     "::0.0.0.0",
+		},
+		{
+			input:     "::ffff:10.1.2.3",
+			canonical: "::ffff:10.1.2.3",
+			expanded:  "0000:0000:0000:0000:0000:ffff:0a01:0203",
+			mixed:     "::ffff:10.1.2.3",
+		},
+		{
+			input:     "64:ff9b::192.0.2.1",
+			canonical: "64:ff9b::192.0.2.1",
+			expanded:  "0064:ff9b:0000:0000:0000:0000:c000:0201",
+			mixed:     "64:ff9b::192.0.2.1",
+		},
+		{
+			input:     "2001:db8::a00:1",
+			canonical: "2001:db8::a00:1",
+			expanded:  "2001:0db8:0000:0000:0000:0000:0a00:0001",
+			mixed:     "2001:db8::10.0.0.1",
+		},
+		{
+			input:     "1:2:3:4:5:6:7:8",
+			canonical: "1:2:3:4:5:6:7:8",
+			expanded:  "0001:0002:0003:0004:0005:0006:0007:0008",
+			mixed:     "1:2:3:4:5:6:0.7.0.8",
+		},
+		{
+			input:     "1:0:0:2:0:0:0:3",
+			canonical: "1:0:0:2::3",
+			expanded:  "0001:0000:0000:0002:0000:0000:0000:0003",
+			mixed:     "1::2:0:0:0.0.0.3",
+		},
+		{
+			input:     "1:0:0:2::",
+			canonical: "1:0:0:2::",
+			expanded:  "0001:0000:0000:0002:0000:0000:0000:0000",
+			mixed:     "1::2:0:0:0.0.0.0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ipv6, err := sockaddr.ParseIPAddr(test.input, sockaddr.ParseOptions{KeepMapped: true})
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			for attr, expected := range map[sockaddr.AttrName]string{
+				"canonical": test.canonical,
+				"expanded":  test.expanded,
+				"mixed":     test.mixed,
+			} {
+				value, err := sockaddr.Attr(ipv6, attr)
+				if err != nil || value != expected {
+					t.Errorf("%s: expected %q, received %q, %v", attr, expected, value, err)
+				}
+
+				// Every style parses back to the same address.
+				if ip := net.ParseIP(value); ip == nil || !ip.Equal(ipv6.NetIP().To16()) {
+					t.Errorf("%s: %q does not parse back to %s", attr, value, test.input)
+				}
+			}
+		})
+	}
+}
+
+func TestIPv6Addr_FormatStyles(t *testing.T) {
+	tests := []struct {
+		input  string
+		style  sockaddr.IPv6Style
+		output string
+	}{
+		{"2001:db8::1", sockaddr.IPv6StyleCanonical, "2001:db8::1"},
+		{"[2001:db8::1]:8080", sockaddr.IPv6StyleExpanded, "[2001:0db8:0000:0000:0000:0000:0000:0001]:8080"},
+		{"2001:db8::/32", sockaddr.IPv6StyleExpanded, "2001:0db8:0000:0000:0000:0000:0000:0000/32"},
+		{"fe80::1%eth0", sockaddr.IPv6StyleExpanded, "fe80:0000:0000:0000:0000:0000:0000:0001%eth0"},
+		{"64:ff9b::/96", sockaddr.IPv6StyleMixed, "64:ff9b::0.0.0.0/96"},
+		{"::ffff:10.1.2.3", sockaddr.IPv6StyleCanonical, "::ffff:10.1.2.3"},
+		{"::ffff:0:0/96", sockaddr.IPv6StyleCanonical, "::ffff:0.0.0.0/96"},
+		{"64:ff9b:1::c000:201", sockaddr.IPv6StyleCanonical, "64:ff9b:1::c000:201"},
+		{"::ffff:10.1.2.3", sockaddr.IPv6StyleExpanded, "0000:0000:0000:0000:0000:ffff:0a01:0203"},
+	}
+
+	for _, test := range tests {
+		ipv6, err := sockaddr.ParseIPAddr(test.input, sockaddr.ParseOptions{KeepMapped: true})
+		if err != nil {
+			t.Fatalf("unable to parse %q: %v", test.input, err)
+		}
+
+		if output := ipv6.(sockaddr.IPv6Addr).Format(test.style); output != test.output {
+			t.Errorf("Format(%s) of %s: expected %q, received %q", test.style, test.input, test.output, output)
+		}
+	}
+
+	// The canonical form matches String() for addresses that are not
+	// IPv4-mapped.
+	for _, s := range []string{"2001:db8::1/64", "[::1]:80", "fe80::1%eth0/64"} {
+		ipv6 := sockaddr.MustIPv6Addr(s)
+		if ipv6.Format(sockaddr.IPv6StyleCanonical) != ipv6.String() {
+			t.Errorf("canonical form of %s: %q vs %q", s, ipv6.Format(sockaddr.IPv6StyleCanonical), ipv6.String())
+		}
+	}
+}
+
+func TestParseIPv6Style(t *testing.T) {
+	for _, style := range []sockaddr.IPv6Style{sockaddr.IPv6StyleCanonical, sockaddr.IPv6StyleExpanded, sockaddr.IPv6StyleMixed} {
+		parsed, err := sockaddr.ParseIPv6Style(style.String())
+		if err != nil || parsed != style {
+			t.Errorf("ParseIPv6Style(%q): received %v, %v", style, parsed, err)
+		}
+	}
+
+	if _, err := sockaddr.ParseIPv6Style("compressed"); err == nil {
+		t.Errorf("expected an unknown style to fail")
+	}
+}
@@ -0,0 +1,281 @@
+package sockaddr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// SocketOption configures the socket created by Listen(), ListenPacket() and
+// Dial().
+type SocketOption func(*socketConfig)
+
+// socketConfig is the set of SocketOptions applied to a socket.
+type socketConfig struct {
+	reuseAddr   bool
+	reusePort   bool
+	v6Only      *bool
+	unixMode    *os.FileMode
+	unixOwner   *[2]int
+	removeStale bool
+	localAddr   SockAddr
+}
+
+// WithReuseAddr sets SO_REUSEADDR on IPv4 and IPv6 sockets (e.g. UDP
+// sockets), allowing them to bind to an address in TIME_WAIT.  The Go runtime
+// already sets SO_REUSEADDR on TCP listeners.  WithReuseAddr is a no-op on
+// Windows, where SO_REUSEADDR would let other sockets hijack the address and
+// where rebinding an address in TIME_WAIT is allowed by default.
+func WithReuseAddr() SocketOption {
+	return func(c *socketConfig) {
+		c.reuseAddr = true
+	}
+}
+
+// WithReusePort sets SO_REUSEPORT on IPv4 and IPv6 sockets, allowing multiple
+// sockets to bind to the same address and port.  SO_REUSEPORT is not
+// supported on every platform (e.g. Windows).
+func WithReusePort() SocketOption {
+	return func(c *socketConfig) {
+		c.reusePort = true
+	}
+}
+
+// WithV6Only sets IPV6_V6ONLY on IPv6 sockets.  If v6Only is false, a socket
+// bound to `[::]` also accepts IPv4 connections as IPv4-mapped IPv6 addresses.
+// By default, IPv6Addr sockets are IPv6-only.
+func WithV6Only(v6Only bool) SocketOption {
+	return func(c *socketConfig) {
+		c.v6Only = &v6Only
+	}
+}
+
+// WithUnixMode sets the permissions of a UNIX socket on the file system.  On
+// platforms with a umask, the permissions not in mode are added to the
+// process umask while the socket is bound, so the socket is never reachable
+// with wider permissions.  The umask is process-wide: files created by other
+// goroutines during the bind are also restricted.  It is ignored for abstract
+// UNIX sockets and for IP sockets.
+func WithUnixMode(mode os.FileMode) SocketOption {
+	return func(c *socketConfig) {
+		c.unixMode = &mode
+	}
+}
+
+// WithUnixOwner sets the owner and group of a UNIX socket on the file system
+// after it has been bound.  A uid or gid of -1 is left unchanged.  It is
+// ignored for abstract UNIX sockets and for IP sockets.
+func WithUnixOwner(uid, gid int) SocketOption {
+	return func(c *socketConfig) {
+		c.unixOwner = &[2]int{uid, gid}
+	}
+}
+
+// WithRemoveStale removes a stale UNIX socket (see UnixSock.IsStale()) before
+// binding to its path.  Paths that are not stale sockets, including sockets
+// with a listening process, are never removed.
+func WithRemoveStale() SocketOption {
+	return func(c *socketConfig) {
+		c.removeStale = true
+	}
+}
+
+// WithLocalAddr sets the local address Dial() binds to before connecting.
+// The SockAddr must be of the same family as the address being dialed.
+func WithLocalAddr(sa SockAddr) SocketOption {
+	return func(c *socketConfig) {
+		c.localAddr = sa
+	}
+}
+
+// Listen creates a stream listener for sa (e.g. a TCP listener for an IPv4Addr
+// or IPv6Addr and a `unix` listener for a UnixSock) using the network and
+// address returned by sa.ListenStreamArgs().
+func Listen(ctx context.Context, sa SockAddr, opts ...SocketOption) (net.Listener, error) {
+	c := newSocketConfig(opts)
+	network, address := sa.ListenStreamArgs()
+	if address == "" {
+		return nil, fmt.Errorf("Unable to listen on %s: not a host address", sa)
+	}
+
+	if err := c.beforeBind(sa); err != nil {
+		return nil, err
+	}
+
+	lc := net.ListenConfig{Control: c.control}
+	restore := c.restrictUnixMode(sa)
+	l, err := lc.Listen(ctx, network, address)
+	restore()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.afterBind(sa); err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// ListenPacket creates a packet listener for sa (e.g. a UDP socket for an
+// IPv4Addr or IPv6Addr and a `unixgram` socket for a UnixSock) using the
+// network and address returned by sa.ListenPacketArgs().
+func ListenPacket(ctx context.Context, sa SockAddr, opts ...SocketOption) (net.PacketConn, error) {
+	c := newSocketConfig(opts)
+	network, address := sa.ListenPacketArgs()
+	if address == "" {
+		return nil, fmt.Errorf("Unable to listen on %s: not a host address", sa)
+	}
+
+	if err := c.beforeBind(sa); err != nil {
+		return nil, err
+	}
+
+	lc := net.ListenConfig{Control: c.control}
+	restore := c.restrictUnixMode(sa)
+	pc, err := lc.ListenPacket(ctx, network, address)
+	restore()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.afterBind(sa); err != nil {
+		pc.Close()
+		return nil, err
+	}
+
+	return pc, nil
+}
+
+// Dial connects to sa using the network and address returned by
+// sa.DialStreamArgs().  sa must be a host address with a port, or a UnixSock.
+func Dial(ctx context.Context, sa SockAddr, opts ...SocketOption) (net.Conn, error) {
+	c := newSocketConfig(opts)
+	network, address := sa.DialStreamArgs()
+	if address == "" {
+		return nil, fmt.Errorf("Unable to dial %s: not a host address with a port", sa)
+	}
+
+	d := net.Dialer{Control: c.control}
+	if c.localAddr != nil {
+		localAddr, err := streamNetAddr(c.localAddr)
+		if err != nil {
+			return nil, err
+		}
+		d.LocalAddr = localAddr
+	}
+
+	return d.DialContext(ctx, network, address)
+}
+
+// newSocketConfig returns the socketConfig for opts.
+func newSocketConfig(opts []SocketOption) *socketConfig {
+	c := &socketConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// beforeBind removes a stale UNIX socket if requested.
+func (c *socketConfig) beforeBind(sa SockAddr) error {
+	us, ok := sa.(UnixSock)
+	if !ok || !c.removeStale || us.IsAbstract() || !us.IsStale() {
+		return nil
+	}
+
+	if err := os.Remove(us.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Unable to remove stale UNIX socket %s: %w", us, err)
+	}
+
+	return nil
+}
+
+// restrictUnixMode narrows the umask to the requested mode of a UNIX socket
+// until restore is called.
+func (c *socketConfig) restrictUnixMode(sa SockAddr) (restore func()) {
+	us, ok := sa.(UnixSock)
+	if !ok || us.IsAbstract() || c.unixMode == nil {
+		return func() {}
+	}
+
+	return narrowUmask(^*c.unixMode & os.ModePerm)
+}
+
+// afterBind sets the mode and owner of a UNIX socket if requested.
+func (c *socketConfig) afterBind(sa SockAddr) error {
+	us, ok := sa.(UnixSock)
+	if !ok || us.IsAbstract() {
+		return nil
+	}
+
+	if c.unixMode != nil {
+		if err := os.Chmod(us.path, *c.unixMode); err != nil {
+			return fmt.Errorf("Unable to set the mode of UNIX socket %s: %w", us, err)
+		}
+	}
+
+	if c.unixOwner != nil {
+		if err := os.Chown(us.path, c.unixOwner[0], c.unixOwner[1]); err != nil {
+			return fmt.Errorf("Unable to set the owner of UNIX socket %s: %w", us, err)
+		}
+	}
+
+	return nil
+}
+
+// control sets the requested socket options on IPv4 and IPv6 sockets before
+// they are bound.  It is a net.ListenConfig and net.Dialer Control function.
+func (c *socketConfig) control(network, address string, rc syscall.RawConn) error {
+	if strings.HasPrefix(network, "unix") {
+		return nil
+	}
+
+	var optErr error
+	err := rc.Control(func(fd uintptr) {
+		if c.reuseAddr {
+			if optErr = setReuseAddr(fd); optErr != nil {
+				optErr = fmt.Errorf("Unable to set SO_REUSEADDR: %w", optErr)
+				return
+			}
+		}
+
+		if c.reusePort {
+			if optErr = setReusePort(fd); optErr != nil {
+				optErr = fmt.Errorf("Unable to set SO_REUSEPORT: %w", optErr)
+				return
+			}
+		}
+
+		if c.v6Only != nil && strings.HasSuffix(network, "6") {
+			if optErr = setV6Only(fd, *c.v6Only); optErr != nil {
+				optErr = fmt.Errorf("Unable to set IPV6_V6ONLY: %w", optErr)
+				return
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return optErr
+}
+
+// streamNetAddr returns sa as a net.Addr suitable for a stream socket.
+func streamNetAddr(sa SockAddr) (net.Addr, error) {
+	switch v := sa.(type) {
+	case IPv4Addr:
+		return v.TCPAddr(), nil
+	case IPv6Addr:
+		return v.TCPAddr(), nil
+	case UnixSock:
+		return v.UnixAddr(), nil
+	default:
+		return nil, fmt.Errorf("Unable to use %s as a local address: unsupported type %s", sa, sa.Type())
+	}
+}
@@ -0,0 +1,231 @@
+package sockaddr_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestListen(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		sa   sockaddr.SockAddr
+		opts []sockaddr.SocketOption
+	}{
+		{name: "ipv4", sa: sockaddr.MustIPv4Addr("127.0.0.1:0")},
+		{name: "ipv4 reuseaddr", sa: sockaddr.MustIPv4Addr("127.0.0.1:0"), opts: []sockaddr.SocketOption{sockaddr.WithReuseAddr()}},
+		{name: "ipv6 v6only", sa: sockaddr.MustIPv6Addr("[::1]:0"), opts: []sockaddr.SocketOption{sockaddr.WithV6Only(true)}},
+		{name: "unix", sa: sockaddr.MustUnixSock(filepath.Join(t.TempDir(), "listen.sock"))},
+	}
+	if runtime.GOOS == "linux" {
+		tests = append(tests, struct {
+			name string
+			sa   sockaddr.SockAddr
+			opts []sockaddr.SocketOption
+		}{name: "ipv4 reuseport", sa: sockaddr.MustIPv4Addr("127.0.0.1:0"), opts: []sockaddr.SocketOption{sockaddr.WithReusePort()}})
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l, err := sockaddr.Listen(ctx, test.sa, test.opts...)
+			if err != nil {
+				if test.sa.Type() == sockaddr.TypeIPv6 {
+					t.Skipf("unable to listen on IPv6: %v", err)
+				}
+				t.Fatalf("unable to listen on %v: %v", test.sa, err)
+			}
+			defer l.Close()
+
+			addr, err := sockaddr.FromNetAddr(l.Addr())
+			if err != nil {
+				t.Fatalf("unable to convert %v: %v", l.Addr(), err)
+			}
+
+			go func() {
+				conn, err := l.Accept()
+				if err == nil {
+					conn.Close()
+				}
+			}()
+
+			conn, err := sockaddr.Dial(ctx, addr)
+			if err != nil {
+				t.Fatalf("unable to dial %v: %v", addr, err)
+			}
+			conn.Close()
+		})
+	}
+
+	if _, err := sockaddr.Listen(ctx, sockaddr.MustIPv4Addr("10.0.0.0/8")); err == nil {
+		t.Fatalf("expected listening on a network to fail")
+	}
+
+	if _, err := sockaddr.Dial(ctx, sockaddr.MustIPv4Addr("127.0.0.1")); err == nil {
+		t.Fatalf("expected dialing without a port to fail")
+	}
+}
+
+func TestListen_ReusePort(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("SO_REUSEPORT semantics differ by platform")
+	}
+
+	ctx := context.Background()
+	l1, err := sockaddr.Listen(ctx, sockaddr.MustIPv4Addr("127.0.0.1:0"), sockaddr.WithReusePort())
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer l1.Close()
+
+	addr, err := sockaddr.FromNetAddr(l1.Addr())
+	if err != nil {
+		t.Fatalf("unable to convert %v: %v", l1.Addr(), err)
+	}
+
+	if l, err := sockaddr.Listen(ctx, addr); err == nil {
+		l.Close()
+		t.Fatalf("expected a second listener without SO_REUSEPORT to fail")
+	}
+
+	l2, err := sockaddr.Listen(ctx, addr, sockaddr.WithReusePort())
+	if err != nil {
+		t.Fatalf("expected a second listener with SO_REUSEPORT to succeed: %v", err)
+	}
+	l2.Close()
+}
+
+func TestListen_Unix(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skip("UNIX socket permissions are not supported on this platform")
+	}
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	us := sockaddr.MustUnixSock(path)
+
+	// Leave a stale socket behind.
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	if l, err := sockaddr.Listen(ctx, us); err == nil {
+		l.Close()
+		t.Fatalf("expected listening on a stale socket to fail")
+	}
+
+	before := filepath.Join(t.TempDir(), "before")
+	if err := os.WriteFile(before, nil, 0666); err != nil {
+		t.Fatalf("unable to create %s: %v", before, err)
+	}
+
+	l, err := sockaddr.Listen(ctx, us, sockaddr.WithRemoveStale(), sockaddr.WithUnixMode(0600), sockaddr.WithUnixOwner(os.Getuid(), -1))
+	if err != nil {
+		t.Fatalf("unable to listen on %v: %v", us, err)
+	}
+	defer l.Close()
+
+	// The umask is restored after binding.
+	after := filepath.Join(t.TempDir(), "after")
+	if err := os.WriteFile(after, nil, 0666); err != nil {
+		t.Fatalf("unable to create %s: %v", after, err)
+	}
+	beforeFi, _ := os.Stat(before)
+	afterFi, _ := os.Stat(after)
+	if beforeFi.Mode().Perm() != afterFi.Mode().Perm() {
+		t.Fatalf("expected the umask to be restored: %04o vs %04o", beforeFi.Mode().Perm(), afterFi.Mode().Perm())
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unable to stat %s: %v", path, err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Fatalf("expected mode 0600, received %04o", fi.Mode().Perm())
+	}
+
+	// A live socket is never removed.
+	if l2, err := sockaddr.Listen(ctx, us, sockaddr.WithRemoveStale()); err == nil {
+		l2.Close()
+		t.Fatalf("expected listening on a live socket to fail")
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the live socket to remain: %v", err)
+	}
+}
+
+func TestListenPacket(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		sa   sockaddr.SockAddr
+	}{
+		{"udp4", sockaddr.MustIPv4Addr("127.0.0.1:0")},
+		{"unixgram", sockaddr.MustUnixSock(filepath.Join(t.TempDir(), "packet.sock"))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pc, err := sockaddr.ListenPacket(ctx, test.sa, sockaddr.WithReuseAddr(), sockaddr.WithUnixMode(0660))
+			if err != nil {
+				t.Fatalf("unable to listen on %v: %v", test.sa, err)
+			}
+			defer pc.Close()
+
+			addr, err := sockaddr.FromNetAddr(pc.LocalAddr())
+			if err != nil {
+				t.Fatalf("unable to convert %v: %v", pc.LocalAddr(), err)
+			}
+			if addr.Type() != test.sa.Type() {
+				t.Fatalf("expected a %s, received %v", test.sa.Type(), addr)
+			}
+		})
+	}
+}
+
+func TestDial_LocalAddr(t *testing.T) {
+	ctx := context.Background()
+	l, err := sockaddr.Listen(ctx, sockaddr.MustIPv4Addr("127.0.0.1:0"))
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer l.Close()
+
+	accepted := make(chan net.Addr, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			accepted <- nil
+			return
+		}
+		accepted <- conn.RemoteAddr()
+		conn.Close()
+	}()
+
+	addr, _ := sockaddr.FromNetAddr(l.Addr())
+	conn, err := sockaddr.Dial(ctx, addr, sockaddr.WithLocalAddr(sockaddr.MustIPv4Addr("127.0.0.1:0")), sockaddr.WithReuseAddr())
+	if err != nil {
+		t.Fatalf("unable to dial %v: %v", addr, err)
+	}
+	defer conn.Close()
+
+	remote := <-accepted
+	if remote == nil || remote.String() != conn.LocalAddr().String() {
+		t.Fatalf("expected the connection to come from %v, received %v", conn.LocalAddr(), remote)
+	}
+
+	if _, err := sockaddr.Dial(ctx, addr, sockaddr.WithLocalAddr(sockaddr.MustHostname("localhost"))); err == nil {
+		t.Fatalf("expected an unsupported local address to fail")
+	}
+}
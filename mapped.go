@@ -0,0 +1,98 @@
+package sockaddr
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ipv4MappedPrefix is the ::ffff:0:0/96 prefix shared by all IPv4-mapped IPv6
+// addresses (RFC 4291, §2.5.5.2), stored in the low 64 bits of a Uint128.
+const ipv4MappedPrefix = uint64(0xffff) << 32
+
+// ipv4MappedMask is the mask of the high 32 bits of the low 64 bits of an
+// IPv6 address, i.e. the portion of a /96 mask preceding the IPv4 address.
+const ipv4MappedMask = uint64(0xffffffff) << 32
+
+// IsMapped returns true if the receiver is an IPv4-mapped IPv6 address (i.e.
+// contained within `::ffff:0:0/96`, e.g. `::ffff:10.0.0.1`).
+func (ipv6 IPv6Addr) IsMapped() bool {
+	addr := Uint128(ipv6.Address)
+	return addr.Hi == 0 && addr.Lo&^0xffffffff == ipv4MappedPrefix
+}
+
+// IsCompat returns true if the receiver is a deprecated IPv4-compatible IPv6
+// address (i.e. contained within `::/96`, e.g. `::10.0.0.1`).  The
+// unspecified address `::` and the loopback address `::1` are not considered
+// IPv4-compatible.
+func (ipv6 IPv6Addr) IsCompat() bool {
+	addr := Uint128(ipv6.Address)
+	return addr.Hi == 0 && addr.Lo>>32 == 0 && addr.Lo > 1
+}
+
+// Unmap returns the IPv4 address embedded in an IPv4-mapped or
+// IPv4-compatible IPv6 address as an IPv4Addr.  The port is preserved and a
+// mask of /96 or longer is converted to the equivalent IPv4 mask (e.g.
+// `::ffff:10.0.0.0/104` becomes `10.0.0.0/8`).  If the receiver is neither
+// mapped nor compatible, or its mask is shorter than /96, the receiver is
+// returned unmodified.
+func (ipv6 IPv6Addr) Unmap() IPAddr {
+	if !ipv6.IsMapped() && !ipv6.IsCompat() {
+		return ipv6
+	}
+
+	if ipv6.Maskbits() < 96 {
+		return ipv6
+	}
+
+	return IPv4Addr{
+		Address: IPv4Address(uint32(Uint128(ipv6.Address).Lo)),
+		Mask:    IPv4Mask(uint32(Uint128(ipv6.Mask).Lo)),
+		Port:    ipv6.Port,
+	}
+}
+
+// ToMapped returns the receiver as an IPv4-mapped IPv6 address (e.g.
+// `10.0.0.0/8` becomes `::ffff:10.0.0.0/104`).  The port is preserved.
+func (ipv4 IPv4Addr) ToMapped() IPv6Addr {
+	return IPv6Addr{
+		Address: IPv6Address(Uint128{Lo: ipv4MappedPrefix | uint64(ipv4.Address)}),
+		Mask:    IPv6Mask(Uint128{Hi: ^uint64(0), Lo: ipv4MappedMask | uint64(ipv4.Mask)}),
+		Port:    ipv4.Port,
+	}
+}
+
+// parseMappedHostPort parses an IPv4-mapped IPv6 address with a port number
+// (e.g. `[::ffff:10.0.0.1]:80`).  ok is false if s is not in this form.
+func parseMappedHostPort(s string) (ipv6 IPv6Addr, ok bool) {
+	if len(s) == 0 || s[0] != '[' {
+		return IPv6Addr{}, false
+	}
+
+	host, portStr, err := net.SplitHostPort(s)
+	if err != nil || strings.IndexByte(host, ':') == -1 {
+		return IPv6Addr{}, false
+	}
+
+	host, zone, err := splitIPv6Zone(host)
+	if err != nil {
+		return IPv6Addr{}, false
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.To4() == nil {
+		return IPv6Addr{}, false
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return IPv6Addr{}, false
+	}
+
+	return IPv6Addr{
+		Address: IPv6Address(netIPToUint128(ip.To16())),
+		Mask:    ipv6HostMask,
+		Port:    IPPort(port),
+		Zone:    zone,
+	}, true
+}
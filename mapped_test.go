@@ -0,0 +1,115 @@
+package sockaddr_test
+
+import (
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestIPv6Addr_Unmap(t *testing.T) {
+	tests := []struct {
+		input    string
+		mapped   bool
+		compat   bool
+		want     string
+		wantType sockaddr.SockAddrType
+	}{
+		{
+			input:    "::ffff:10.0.0.1",
+			mapped:   true,
+			want:     "10.0.0.1",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			input:    "[::ffff:10.0.0.1]:8080",
+			mapped:   true,
+			want:     "10.0.0.1:8080",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			input:    "::ffff:10.0.0.0/104",
+			mapped:   true,
+			want:     "10.0.0.0/8",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			input:    "::10.0.0.1",
+			compat:   true,
+			want:     "10.0.0.1",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			// Masks shorter than /96 can not be represented in IPv4
+			input:    "::ffff:10.0.0.0/80",
+			mapped:   true,
+			want:     "::ffff:10.0.0.0/80",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			input:    "::1",
+			want:     "::1",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			input:    "::",
+			want:     "::",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			input:    "2001:db8::10.0.0.1",
+			want:     "2001:db8::a00:1",
+			wantType: sockaddr.TypeIPv6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ipv6 := sockaddr.MustIPv6Addr(test.input)
+			if ipv6.IsMapped() != test.mapped {
+				t.Errorf("IsMapped: expected %t", test.mapped)
+			}
+			if ipv6.IsCompat() != test.compat {
+				t.Errorf("IsCompat: expected %t", test.compat)
+			}
+
+			ipAddr := ipv6.Unmap()
+			if ipAddr.Type() != test.wantType {
+				t.Errorf("wrong type: %s vs %s", ipAddr.Type(), test.wantType)
+			}
+			if ipAddr.String() != test.want {
+				t.Errorf("wrong string: %q vs %q", ipAddr.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestIPv4Addr_ToMapped(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "10.0.0.1", want: "::ffff:10.0.0.1"},
+		{input: "10.0.0.1:80", want: "[::ffff:10.0.0.1]:80"},
+		{input: "10.0.0.0/8", want: "::ffff:10.0.0.0/104"},
+		{input: "0.0.0.0/0", want: "::ffff:0.0.0.0/96"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ipv4 := sockaddr.MustIPv4Addr(test.input)
+			ipv6 := ipv4.ToMapped()
+			if !ipv6.IsMapped() {
+				t.Errorf("expected %q to be mapped", ipv6)
+			}
+			if ipv6.String() != test.want {
+				t.Errorf("wrong string: %q vs %q", ipv6.String(), test.want)
+			}
+			if !ipv6.Equal(sockaddr.MustIPv6Addr(test.want)) {
+				t.Errorf("expected %q to round-trip", test.want)
+			}
+			if got := ipv6.Unmap(); !got.Equal(ipv4) {
+				t.Errorf("failed round-trip: %q vs %q", got, ipv4)
+			}
+		})
+	}
+}
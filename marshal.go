@@ -0,0 +1,511 @@
+package sockaddr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net"
+)
+
+// The binary form of each SockAddr type starts with a one byte family tag, the
+// SockAddr's Type(), followed by the type-specific fields in network byte
+// order.  IPv4Addr and IPv6Addr store their netmask as a one byte prefix
+// length.
+const (
+	ipv4BinaryLen = 1 + IPv4len + 1 + 2
+	ipv6BinaryLen = 1 + IPv6len + 1 + 2
+)
+
+// MarshalText implements encoding.TextMarshaler and returns the same value as
+// String().  The mask of an IPv4Addr with a port is not included.
+func (ipv4 IPv4Addr) MarshalText() ([]byte, error) {
+	return []byte(ipv4.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewIPv4Addr().
+func (ipv4 *IPv4Addr) UnmarshalText(text []byte) error {
+	v, err := NewIPv4Addr(string(text))
+	if err != nil {
+		return err
+	}
+
+	*ipv4 = v
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The binary form is 8
+// bytes long: the family tag, the address, the prefix length and the port.
+// MarshalBinary fails if the netmask is not contiguous.
+func (ipv4 IPv4Addr) MarshalBinary() ([]byte, error) {
+	ones := bits.OnesCount32(uint32(ipv4.Mask))
+	if uint32(ipv4.Mask) != ^uint32(0)<<uint(IPv4len*8-ones) {
+		return nil, fmt.Errorf("Unable to marshal %s: non-contiguous netmask %s", ipv4, ipv4.NetIPMask())
+	}
+
+	b := make([]byte, ipv4BinaryLen)
+	b[0] = byte(TypeIPv4)
+	binary.BigEndian.PutUint32(b[1:], uint32(ipv4.Address))
+	b[1+IPv4len] = byte(ones)
+	binary.BigEndian.PutUint16(b[2+IPv4len:], uint16(ipv4.Port))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (ipv4 *IPv4Addr) UnmarshalBinary(data []byte) error {
+	if len(data) != ipv4BinaryLen || data[0] != byte(TypeIPv4) {
+		return fmt.Errorf("Unable to unmarshal an IPv4Addr: invalid data %x", data)
+	}
+
+	ones := int(data[1+IPv4len])
+	if ones > IPv4len*8 {
+		return fmt.Errorf("Unable to unmarshal an IPv4Addr: %w /%d", ErrInvalidMask, ones)
+	}
+
+	*ipv4 = IPv4Addr{
+		Address: IPv4Address(binary.BigEndian.Uint32(data[1:])),
+		Mask:    IPv4Mask(^uint32(0) << uint(IPv4len*8-ones)),
+		Port:    IPPort(binary.BigEndian.Uint16(data[2+IPv4len:])),
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the same value as
+// String().  The mask of an IPv6Addr with a port is not included.
+func (ipv6 IPv6Addr) MarshalText() ([]byte, error) {
+	return []byte(ipv6.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewIPv6Addr().
+func (ipv6 *IPv6Addr) UnmarshalText(text []byte) error {
+	v, err := NewIPv6Addr(string(text))
+	if err != nil {
+		return err
+	}
+
+	*ipv6 = v
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The binary form is the
+// family tag, the address, the prefix length, the port and the zone, if any.
+// MarshalBinary fails if the netmask is not contiguous.
+func (ipv6 IPv6Addr) MarshalBinary() ([]byte, error) {
+	mask := Uint128(ipv6.Mask)
+	ones := mask.OnesCount()
+	if mask != uint128Mask(ones) {
+		return nil, fmt.Errorf("Unable to marshal %s: non-contiguous netmask %s", ipv6, ipv6.NetIPMask())
+	}
+
+	b := make([]byte, ipv6BinaryLen, ipv6BinaryLen+len(ipv6.Zone))
+	b[0] = byte(TypeIPv6)
+	addr := Uint128(ipv6.Address).Bytes()
+	copy(b[1:], addr[:])
+	b[1+IPv6len] = byte(ones)
+	binary.BigEndian.PutUint16(b[2+IPv6len:], uint16(ipv6.Port))
+	return append(b, ipv6.Zone...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (ipv6 *IPv6Addr) UnmarshalBinary(data []byte) error {
+	if len(data) < ipv6BinaryLen || data[0] != byte(TypeIPv6) {
+		return fmt.Errorf("Unable to unmarshal an IPv6Addr: invalid data %x", data)
+	}
+
+	ones := int(data[1+IPv6len])
+	if ones > IPv6len*8 {
+		return fmt.Errorf("Unable to unmarshal an IPv6Addr: %w /%d", ErrInvalidMask, ones)
+	}
+
+	var addr [IPv6len]byte
+	copy(addr[:], data[1:])
+	*ipv6 = IPv6Addr{
+		Address: IPv6Address(Uint128FromBytes(addr)),
+		Mask:    IPv6Mask(uint128Mask(ones)),
+		Port:    IPPort(binary.BigEndian.Uint16(data[2+IPv6len:])),
+		Zone:    string(data[ipv6BinaryLen:]),
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the unquoted
+// path of the UnixSock.
+func (us UnixSock) MarshalText() ([]byte, error) {
+	return []byte(us.path), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewUnixSock().
+func (us *UnixSock) UnmarshalText(text []byte) error {
+	v, err := NewUnixSock(string(text))
+	if err != nil {
+		return err
+	}
+
+	*us = v
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The binary form is the
+// family tag followed by the path.
+func (us UnixSock) MarshalBinary() ([]byte, error) {
+	return append([]byte{byte(TypeUnix)}, us.path...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (us *UnixSock) UnmarshalBinary(data []byte) error {
+	if len(data) < 1 || data[0] != byte(TypeUnix) {
+		return fmt.Errorf("Unable to unmarshal a UnixSock: invalid data %x", data)
+	}
+
+	return us.UnmarshalText(data[1:])
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the same value as
+// String().
+func (h Hostname) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewHostname().
+func (h *Hostname) UnmarshalText(text []byte) error {
+	v, err := NewHostname(string(text))
+	if err != nil {
+		return err
+	}
+
+	*h = v
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The binary form is the
+// family tag, the port and the host.
+func (h Hostname) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 3, 3+len(h.Host))
+	b[0] = byte(TypeHostname)
+	binary.BigEndian.PutUint16(b[1:], uint16(h.Port))
+	return append(b, h.Host...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (h *Hostname) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 || data[0] != byte(TypeHostname) {
+		return fmt.Errorf("Unable to unmarshal a Hostname: invalid data %x", data)
+	}
+
+	v, err := NewHostname(string(data[3:]))
+	if err != nil {
+		return err
+	}
+
+	v.Port = IPPort(binary.BigEndian.Uint16(data[1:]))
+	*h = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the same value as
+// String().
+func (r IPPortRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewIPPortRange().
+func (r *IPPortRange) UnmarshalText(text []byte) error {
+	v, err := NewIPPortRange(string(text))
+	if err != nil {
+		return err
+	}
+
+	*r = v
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The binary form is the
+// family tag, the low and high ports and the binary form of the address.
+func (r IPPortRange) MarshalBinary() ([]byte, error) {
+	if r.Addr == nil {
+		return nil, fmt.Errorf("Unable to marshal an IPPortRange without an address")
+	}
+
+	addr, err := marshalSockAddrBinary(r.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 5, 5+len(addr))
+	b[0] = byte(TypeIPPortRange)
+	binary.BigEndian.PutUint16(b[1:], uint16(r.Low))
+	binary.BigEndian.PutUint16(b[3:], uint16(r.High))
+	return append(b, addr...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *IPPortRange) UnmarshalBinary(data []byte) error {
+	if len(data) < 5 || data[0] != byte(TypeIPPortRange) {
+		return fmt.Errorf("Unable to unmarshal an IPPortRange: invalid data %x", data)
+	}
+
+	sa, err := unmarshalSockAddrBinary(data[5:])
+	if err != nil {
+		return err
+	}
+
+	ipAddr, ok := sa.(IPAddr)
+	if !ok {
+		return fmt.Errorf("Unable to unmarshal an IPPortRange: unsupported address type %s", sa.Type())
+	}
+
+	low := IPPort(binary.BigEndian.Uint16(data[1:]))
+	high := IPPort(binary.BigEndian.Uint16(data[3:]))
+	if low > high {
+		return fmt.Errorf("Unable to unmarshal an IPPortRange: invalid port range %d-%d", low, high)
+	}
+
+	*r = IPPortRange{
+		Addr: ipAddr,
+		Low:  low,
+		High: high,
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the same value as
+// String().
+func (r IPRange) MarshalText() ([]byte, error) {
+	if r.First == nil || r.Last == nil {
+		return nil, fmt.Errorf("Unable to marshal an IPRange without addresses")
+	}
+
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewIPRange().
+func (r *IPRange) UnmarshalText(text []byte) error {
+	v, err := NewIPRange(string(text))
+	if err != nil {
+		return err
+	}
+
+	*r = v
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The binary form is the
+// family tag, the uvarint length of the binary form of the first address, and
+// the binary forms of the first and last addresses.
+func (r IPRange) MarshalBinary() ([]byte, error) {
+	if r.First == nil || r.Last == nil {
+		return nil, fmt.Errorf("Unable to marshal an IPRange without addresses")
+	}
+
+	first, err := marshalSockAddrBinary(r.First)
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := marshalSockAddrBinary(r.Last)
+	if err != nil {
+		return nil, err
+	}
+
+	b := appendUvarint([]byte{byte(TypeIPRange)}, uint64(len(first)))
+	b = append(b, first...)
+	return append(b, last...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *IPRange) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != byte(TypeIPRange) {
+		return fmt.Errorf("Unable to unmarshal an IPRange: invalid data %x", data)
+	}
+
+	firstLen, n := binary.Uvarint(data[1:])
+	if n <= 0 || firstLen > uint64(len(data)-1-n) {
+		return fmt.Errorf("Unable to unmarshal an IPRange: invalid data %x", data)
+	}
+	data = data[1+n:]
+
+	var addrs [2]IPAddr
+	for i, b := range [][]byte{data[:firstLen], data[firstLen:]} {
+		sa, err := unmarshalSockAddrBinary(b)
+		if err != nil {
+			return err
+		}
+
+		ipAddr, ok := sa.(IPAddr)
+		if !ok {
+			return fmt.Errorf("Unable to unmarshal an IPRange: unsupported address type %s", sa.Type())
+		}
+		addrs[i] = ipAddr
+	}
+
+	v, err := IPRangeFromAddrs(addrs[0], addrs[1])
+	if err != nil {
+		return fmt.Errorf("Unable to unmarshal an IPRange: %w", err)
+	}
+
+	*r = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the text form of
+// the IfAddr's SockAddr.  The Interface is not included, use MarshalBinary()
+// to preserve it.
+func (ifAddr IfAddr) MarshalText() ([]byte, error) {
+	if ifAddr.SockAddr == nil {
+		return nil, fmt.Errorf("Unable to marshal an IfAddr without a SockAddr")
+	}
+
+	if tm, ok := ifAddr.SockAddr.(interface{ MarshalText() ([]byte, error) }); ok {
+		return tm.MarshalText()
+	}
+
+	return []byte(ifAddr.SockAddr.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using NewSockAddr(),
+// except that IPv4-mapped IPv6 addresses are kept as an IPv6Addr.  The
+// Interface is reset.
+func (ifAddr *IfAddr) UnmarshalText(text []byte) error {
+	sa, err := ParseSockAddr(string(text), ParseOptions{KeepMapped: true})
+	if err != nil {
+		return err
+	}
+
+	*ifAddr = IfAddr{SockAddr: sa}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The binary form is the
+// length and binary form of the SockAddr, followed by the Interface's index,
+// MTU, flags, name and hardware address.  Lengths, the index, MTU and flags
+// are encoded as uvarints.
+func (ifAddr IfAddr) MarshalBinary() ([]byte, error) {
+	if ifAddr.SockAddr == nil {
+		return nil, fmt.Errorf("Unable to marshal an IfAddr without a SockAddr")
+	}
+
+	sa, err := marshalSockAddrBinary(ifAddr.SockAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	intf := ifAddr.Interface
+	b := make([]byte, 0, 5*binary.MaxVarintLen64+len(sa)+len(intf.Name)+len(intf.HardwareAddr))
+	b = appendUvarint(b, uint64(len(sa)))
+	b = append(b, sa...)
+	b = appendUvarint(b, uint64(intf.Index))
+	b = appendUvarint(b, uint64(intf.MTU))
+	b = appendUvarint(b, uint64(intf.Flags))
+	b = appendUvarint(b, uint64(len(intf.Name)))
+	b = append(b, intf.Name...)
+	return append(b, intf.HardwareAddr...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (ifAddr *IfAddr) UnmarshalBinary(data []byte) error {
+	saLen, n := binary.Uvarint(data)
+	if n <= 0 || saLen > uint64(len(data)-n) {
+		return fmt.Errorf("Unable to unmarshal an IfAddr: invalid data %x", data)
+	}
+	data = data[n:]
+
+	sa, err := unmarshalSockAddrBinary(data[:saLen])
+	if err != nil {
+		return err
+	}
+	data = data[saLen:]
+
+	var fields [4]uint64
+	for i := range fields {
+		fields[i], n = binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("Unable to unmarshal an IfAddr: truncated interface")
+		}
+		data = data[n:]
+	}
+
+	nameLen := fields[3]
+	if nameLen > uint64(len(data)) {
+		return fmt.Errorf("Unable to unmarshal an IfAddr: truncated interface name")
+	}
+
+	var hwAddr net.HardwareAddr
+	if len(data[nameLen:]) > 0 {
+		hwAddr = append(net.HardwareAddr(nil), data[nameLen:]...)
+	}
+
+	*ifAddr = IfAddr{
+		SockAddr: sa,
+		Interface: net.Interface{
+			Index:        int(fields[0]),
+			MTU:          int(fields[1]),
+			Flags:        net.Flags(fields[2]),
+			Name:         string(data[:nameLen]),
+			HardwareAddr: hwAddr,
+		},
+	}
+	return nil
+}
+
+// marshalSockAddrBinary returns the binary form of sa.
+func marshalSockAddrBinary(sa SockAddr) ([]byte, error) {
+	switch v := sa.(type) {
+	case IPv4Addr:
+		return v.MarshalBinary()
+	case IPv6Addr:
+		return v.MarshalBinary()
+	case UnixSock:
+		return v.MarshalBinary()
+	case Hostname:
+		return v.MarshalBinary()
+	case IPPortRange:
+		return v.MarshalBinary()
+	case IPRange:
+		return v.MarshalBinary()
+	default:
+		return nil, fmt.Errorf("Unable to marshal unsupported type %T", sa)
+	}
+}
+
+// unmarshalSockAddrBinary returns the SockAddr for the binary form in data,
+// using the family tag to select its type.
+func unmarshalSockAddrBinary(data []byte) (SockAddr, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("Unable to unmarshal a SockAddr: no data")
+	}
+
+	switch SockAddrType(data[0]) {
+	case TypeIPv4:
+		var v IPv4Addr
+		err := v.UnmarshalBinary(data)
+		return v, err
+	case TypeIPv6:
+		var v IPv6Addr
+		err := v.UnmarshalBinary(data)
+		return v, err
+	case TypeUnix:
+		var v UnixSock
+		err := v.UnmarshalBinary(data)
+		return v, err
+	case TypeHostname:
+		var v Hostname
+		err := v.UnmarshalBinary(data)
+		return v, err
+	case TypeIPPortRange:
+		var v IPPortRange
+		err := v.UnmarshalBinary(data)
+		return v, err
+	case TypeIPRange:
+		var v IPRange
+		err := v.UnmarshalBinary(data)
+		return v, err
+	default:
+		return nil, fmt.Errorf("Unable to unmarshal a SockAddr: unknown family tag 0x%02x", data[0])
+	}
+}
+
+// appendUvarint appends the uvarint encoding of x to b.
+func appendUvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	return append(b, buf[:n]...)
+}
@@ -0,0 +1,290 @@
+package sockaddr_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+var (
+	_ encoding.TextMarshaler     = sockaddr.IPv4Addr{}
+	_ encoding.TextUnmarshaler   = (*sockaddr.IPv4Addr)(nil)
+	_ encoding.BinaryMarshaler   = sockaddr.IPv6Addr{}
+	_ encoding.BinaryUnmarshaler = (*sockaddr.IPv6Addr)(nil)
+	_ encoding.TextMarshaler     = sockaddr.UnixSock{}
+	_ encoding.BinaryUnmarshaler = (*sockaddr.UnixSock)(nil)
+	_ encoding.TextMarshaler     = sockaddr.IfAddr{}
+	_ encoding.BinaryUnmarshaler = (*sockaddr.IfAddr)(nil)
+)
+
+func TestSockAddr_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   encoding.TextMarshaler
+		out  encoding.TextUnmarshaler
+		text string
+	}{
+		{"ipv4", sockaddr.MustIPv4Addr("10.0.0.1:80"), new(sockaddr.IPv4Addr), "10.0.0.1:80"},
+		{"ipv4 cidr", sockaddr.MustIPv4Addr("10.0.0.0/8"), new(sockaddr.IPv4Addr), "10.0.0.0/8"},
+		{"ipv6", sockaddr.MustIPv6Addr("[2001:db8::1]:8080"), new(sockaddr.IPv6Addr), "[2001:db8::1]:8080"},
+		{"ipv6 cidr", sockaddr.MustIPv6Addr("2001:db8::/32"), new(sockaddr.IPv6Addr), "2001:db8::/32"},
+		{"ipv6 zone", sockaddr.MustIPv6Addr("fe80::1%eth0"), new(sockaddr.IPv6Addr), "fe80::1%eth0"},
+		{"unix", sockaddr.MustUnixSock("/tmp/my sock"), new(sockaddr.UnixSock), "/tmp/my sock"},
+		{"abstract", sockaddr.MustUnixSock("@agent"), new(sockaddr.UnixSock), "@agent"},
+		{"hostname", sockaddr.MustHostname("db.internal:5432"), new(sockaddr.Hostname), "db.internal:5432"},
+		{"port range", sockaddr.MustIPPortRange("[2001:db8::/32]:8000-8100"), new(sockaddr.IPPortRange), "[2001:db8::/32]:8000-8100"},
+		{"ip range", sockaddr.MustIPRange("2001:db8::10-2001:db8::ff"), new(sockaddr.IPRange), "2001:db8::10-2001:db8::ff"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, err := test.in.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText: %v", err)
+			}
+
+			if string(text) != test.text {
+				t.Fatalf("MarshalText: expected %q, received %q", test.text, text)
+			}
+
+			if err := test.out.UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText: %v", err)
+			}
+
+			if !test.out.(sockaddr.SockAddr).Equal(test.in.(sockaddr.SockAddr)) {
+				t.Fatalf("UnmarshalText: expected %v, received %v", test.in, test.out)
+			}
+		})
+	}
+}
+
+func TestSockAddr_MarshalBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		in   sockaddr.SockAddr
+		out  encoding.BinaryUnmarshaler
+		len  int
+	}{
+		{"ipv4", sockaddr.MustIPv4Addr("10.0.0.1:80"), new(sockaddr.IPv4Addr), 8},
+		{"ipv4 cidr", sockaddr.MustIPv4Addr("10.1.0.0/16"), new(sockaddr.IPv4Addr), 8},
+		{"ipv4 any", sockaddr.MustIPv4Addr("0.0.0.0/0"), new(sockaddr.IPv4Addr), 8},
+		{"ipv6", sockaddr.MustIPv6Addr("[2001:db8::1]:8080"), new(sockaddr.IPv6Addr), 20},
+		{"ipv6 cidr", sockaddr.MustIPv6Addr("2001:db8::/32"), new(sockaddr.IPv6Addr), 20},
+		{"ipv6 zone", sockaddr.MustIPv6Addr("fe80::1%eth0"), new(sockaddr.IPv6Addr), 24},
+		{"unix", sockaddr.MustUnixSock("/tmp/agent.sock"), new(sockaddr.UnixSock), 16},
+		{"abstract", sockaddr.MustUnixSock("@agent"), new(sockaddr.UnixSock), 7},
+		{"hostname", sockaddr.MustHostname("db.internal:5432"), new(sockaddr.Hostname), 14},
+		{"port range", sockaddr.MustIPPortRange("10.0.0.0/8:8000-8100"), new(sockaddr.IPPortRange), 13},
+		{"ip range", sockaddr.MustIPRange("10.0.0.10-10.0.0.200"), new(sockaddr.IPRange), 18},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := test.in.(encoding.BinaryMarshaler).MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary: %v", err)
+			}
+
+			if len(data) != test.len {
+				t.Fatalf("MarshalBinary: expected %d bytes, received %d: %x", test.len, len(data), data)
+			}
+
+			if data[0] != byte(test.in.Type()) {
+				t.Fatalf("MarshalBinary: expected family tag %#x, received %#x", byte(test.in.Type()), data[0])
+			}
+
+			if err := test.out.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary: %v", err)
+			}
+
+			if !test.out.(sockaddr.SockAddr).Equal(test.in) {
+				t.Fatalf("UnmarshalBinary: expected %v, received %v", test.in, test.out)
+			}
+		})
+	}
+}
+
+func TestSockAddr_MarshalPreservesMaskAndPort(t *testing.T) {
+	// The text form of an address with a port can not carry its mask, the
+	// binary form can.
+	ipv4 := sockaddr.MustIPv4Addr("10.1.2.3/16")
+	ipv4.Port = 8443
+
+	data, err := ipv4.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var got sockaddr.IPv4Addr
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if got != ipv4 {
+		t.Fatalf("expected %#v, received %#v", ipv4, got)
+	}
+
+	ipv6 := sockaddr.MustIPv6Addr("2001:db8::1/64")
+	ipv6.Port = 53
+	data, err = ipv6.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var got6 sockaddr.IPv6Addr
+	if err := got6.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if got6 != ipv6 {
+		t.Fatalf("expected %#v, received %#v", ipv6, got6)
+	}
+}
+
+func TestSockAddr_MarshalJSONAndGob(t *testing.T) {
+	type config struct {
+		Bind   sockaddr.IPv4Addr
+		Listen sockaddr.IPv6Addr
+		Socket sockaddr.UnixSock
+	}
+
+	in := config{
+		Bind:   sockaddr.MustIPv4Addr("192.168.1.10:8500"),
+		Listen: sockaddr.MustIPv6Addr("[::1]:8600"),
+		Socket: sockaddr.MustUnixSock("/var/run/agent.sock"),
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	const expected = `{"Bind":"192.168.1.10:8500","Listen":"[::1]:8600","Socket":"/var/run/agent.sock"}`
+	if string(data) != expected {
+		t.Fatalf("json.Marshal: expected %s, received %s", expected, data)
+	}
+
+	var out config
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	if !out.Bind.Equal(in.Bind) || !out.Listen.Equal(in.Listen) || !out.Socket.Equal(in.Socket) {
+		t.Fatalf("json.Unmarshal: expected %+v, received %+v", in, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Bind":"::1"}`), &out); err == nil {
+		t.Fatalf("json.Unmarshal: expected an error for an IPv6 address in an IPv4Addr")
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("gob.Encode: %v", err)
+	}
+
+	var gobOut config
+	if err := gob.NewDecoder(&buf).Decode(&gobOut); err != nil {
+		t.Fatalf("gob.Decode: %v", err)
+	}
+
+	if !gobOut.Bind.Equal(in.Bind) || !gobOut.Listen.Equal(in.Listen) || !gobOut.Socket.Equal(in.Socket) {
+		t.Fatalf("gob.Decode: expected %+v, received %+v", in, gobOut)
+	}
+}
+
+func TestIfAddr_Marshal(t *testing.T) {
+	in := sockaddr.IfAddr{
+		SockAddr: sockaddr.MustIPv6Addr("2001:db8::10/64"),
+		Interface: net.Interface{
+			Index:        3,
+			MTU:          9000,
+			Name:         "eth0",
+			HardwareAddr: net.HardwareAddr{0x02, 0x42, 0xac, 0x11, 0x00, 0x02},
+			Flags:        net.FlagUp | net.FlagMulticast,
+		},
+	}
+
+	data, err := in.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var out sockaddr.IfAddr
+	if err := out.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if !out.SockAddr.Equal(in.SockAddr) {
+		t.Fatalf("UnmarshalBinary: expected SockAddr %v, received %v", in.SockAddr, out.SockAddr)
+	}
+
+	if out.Index != in.Index || out.MTU != in.MTU || out.Name != in.Name || out.Flags != in.Flags || out.HardwareAddr.String() != in.HardwareAddr.String() {
+		t.Fatalf("UnmarshalBinary: expected Interface %+v, received %+v", in.Interface, out.Interface)
+	}
+
+	text, err := in.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText: %v", err)
+	}
+
+	if string(text) != "2001:db8::10/64" {
+		t.Fatalf("MarshalText: expected %q, received %q", "2001:db8::10/64", text)
+	}
+
+	var textOut sockaddr.IfAddr
+	if err := textOut.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText: %v", err)
+	}
+
+	if !textOut.SockAddr.Equal(in.SockAddr) || textOut.Name != "" {
+		t.Fatalf("UnmarshalText: expected %v without an interface, received %+v", in.SockAddr, textOut)
+	}
+
+	if _, err := (sockaddr.IfAddr{}).MarshalBinary(); err == nil {
+		t.Fatalf("MarshalBinary: expected an error for an IfAddr without a SockAddr")
+	}
+}
+
+func TestSockAddr_UnmarshalErrors(t *testing.T) {
+	ipv4 := sockaddr.MustIPv4Addr("10.0.0.1")
+	ipv4.Mask = 0xff00ff00
+	if _, err := ipv4.MarshalBinary(); err == nil {
+		t.Fatalf("MarshalBinary: expected an error for a non-contiguous netmask")
+	}
+
+	tests := []struct {
+		name string
+		out  encoding.BinaryUnmarshaler
+		data []byte
+	}{
+		{"ipv4 empty", new(sockaddr.IPv4Addr), nil},
+		{"ipv4 short", new(sockaddr.IPv4Addr), []byte{byte(sockaddr.TypeIPv4), 10, 0, 0, 1}},
+		{"ipv4 wrong tag", new(sockaddr.IPv4Addr), []byte{byte(sockaddr.TypeIPv6), 10, 0, 0, 1, 32, 0, 0}},
+		{"ipv6 short", new(sockaddr.IPv6Addr), []byte{byte(sockaddr.TypeIPv6), 0, 0}},
+		{"unix wrong tag", new(sockaddr.UnixSock), []byte{byte(sockaddr.TypeIPv4), '/'}},
+		{"hostname invalid", new(sockaddr.Hostname), []byte{byte(sockaddr.TypeHostname), 0, 80, '-'}},
+		{"port range inverted", new(sockaddr.IPPortRange), []byte{byte(sockaddr.TypeIPPortRange), 0, 81, 0, 80, byte(sockaddr.TypeIPv4), 10, 0, 0, 1, 32, 0, 0}},
+		{"ifaddr truncated", new(sockaddr.IfAddr), []byte{8, byte(sockaddr.TypeIPv4), 10, 0, 0, 1, 32, 0, 0, 1}},
+		{"ifaddr unknown tag", new(sockaddr.IfAddr), []byte{1, 0xff, 0, 0, 0, 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.out.UnmarshalBinary(test.data); err == nil {
+				t.Fatalf("UnmarshalBinary: expected an error for %x", test.data)
+			}
+		})
+	}
+
+	var bad sockaddr.IPv4Addr
+	err := bad.UnmarshalBinary([]byte{byte(sockaddr.TypeIPv4), 10, 0, 0, 1, 33, 0, 0})
+	if !errors.Is(err, sockaddr.ErrInvalidMask) {
+		t.Fatalf("UnmarshalBinary: expected ErrInvalidMask, received %v", err)
+	}
+}
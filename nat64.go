@@ -0,0 +1,141 @@
+package sockaddr
+
+import (
+	"fmt"
+)
+
+// nat64WellKnownPrefix is the RFC 6052 Well-Known Prefix, 64:ff9b::/96.
+var nat64WellKnownPrefix = MustIPv6Addr("64:ff9b::/96")
+
+// sixToFourPrefix is the 6to4 prefix, 2002::/16 (RFC 3056).
+var sixToFourPrefix = MustIPv6Addr("2002::/16")
+
+// nat64Octets returns the positions, in the 16 octets of an IPv6 address, of
+// the four octets of an IPv4 address embedded behind a prefix of prefixLen
+// (RFC 6052, §2.2).  Octet 8 (bits 64 to 71), the "u" octet, is always
+// skipped.  ok is false if prefixLen is not a valid RFC 6052 prefix length.
+func nat64Octets(prefixLen int) (octets [IPv4len]int, ok bool) {
+	switch prefixLen {
+	case 32:
+		return [IPv4len]int{4, 5, 6, 7}, true
+	case 40:
+		return [IPv4len]int{5, 6, 7, 9}, true
+	case 48:
+		return [IPv4len]int{6, 7, 9, 10}, true
+	case 56:
+		return [IPv4len]int{7, 9, 10, 11}, true
+	case 64:
+		return [IPv4len]int{9, 10, 11, 12}, true
+	case 96:
+		return [IPv4len]int{12, 13, 14, 15}, true
+	default:
+		return octets, false
+	}
+}
+
+// EmbedIPv4 returns the IPv4-embedded IPv6 address of v4 behind a NAT64
+// prefix (RFC 6052, §2.2), e.g. `64:ff9b::192.0.2.33` for `192.0.2.33` and the
+// Well-Known Prefix `64:ff9b::/96`, or `2001:db8:c000:221::` for the prefix
+// `2001:db8::/32`.  The prefix length must be 32, 40, 48, 56, 64 or 96.  Octet
+// 8 of the address, the "u" octet, and the suffix are set to zero, so a /96
+// prefix must have a zero "u" octet.  The port of v4 is preserved.
+func EmbedIPv4(prefix IPv6Addr, v4 IPv4Addr) (IPv6Addr, error) {
+	ones, err := prefix.prefixLen()
+	if err != nil {
+		return IPv6Addr{}, err
+	}
+
+	octets, ok := nat64Octets(ones)
+	if !ok {
+		return IPv6Addr{}, fmt.Errorf("Unable to embed %s in %s: %w, NAT64 prefix length must be 32, 40, 48, 56, 64 or 96", v4.NetIP(), prefix.Network(), ErrInvalidMask)
+	}
+
+	b := Uint128(prefix.NetworkAddress()).Bytes()
+	if b[8] != 0 {
+		return IPv6Addr{}, fmt.Errorf("Unable to embed %s in %s: bits 64 to 71 of the prefix must be zero", v4.NetIP(), prefix.Network())
+	}
+
+	v4Bytes := [IPv4len]byte{byte(v4.Address >> 24), byte(v4.Address >> 16), byte(v4.Address >> 8), byte(v4.Address)}
+	for i, pos := range octets {
+		b[pos] = v4Bytes[i]
+	}
+
+	return IPv6Addr{
+		Address: IPv6Address(Uint128FromBytes(b)),
+		Mask:    ipv6HostMask,
+		Port:    v4.Port,
+	}, nil
+}
+
+// ExtractIPv4 returns the IPv4 address embedded in an IPv4-embedded IPv6
+// address (RFC 6052, §2.2), the inverse of EmbedIPv4().  The NAT64 prefix
+// length is the prefix length of v6, which must be 32, 40, 48, 56, 64 or 96
+// (e.g. `2001:db8:c000:221::/32` returns `192.0.2.33`).  Host addresses are
+// assumed to use a /96 prefix, such as the Well-Known Prefix (e.g.
+// `64:ff9b::192.0.2.33` returns `192.0.2.33`).  The port of v6 is preserved.
+func ExtractIPv4(v6 IPv6Addr) (IPv4Addr, error) {
+	ones, err := v6.prefixLen()
+	if err != nil {
+		return IPv4Addr{}, err
+	}
+
+	if ones == IPv6len*8 {
+		ones = 96
+	}
+
+	octets, ok := nat64Octets(ones)
+	if !ok {
+		return IPv4Addr{}, fmt.Errorf("Unable to extract an IPv4 address from %s: %w, NAT64 prefix length must be 32, 40, 48, 56, 64 or 96", v6, ErrInvalidMask)
+	}
+
+	b := Uint128(v6.Address).Bytes()
+	if b[8] != 0 {
+		return IPv4Addr{}, fmt.Errorf("Unable to extract an IPv4 address from %s: bits 64 to 71 must be zero", v6)
+	}
+
+	var address uint32
+	for _, pos := range octets {
+		address = address<<8 | uint32(b[pos])
+	}
+
+	return IPv4Addr{
+		Address: IPv4Address(address),
+		Mask:    IPv4HostMask,
+		Port:    v6.Port,
+	}, nil
+}
+
+// IsISATAP returns true if the interface identifier of the IPv6Addr is an
+// ISATAP identifier (RFC 5214, §6.1), i.e. `0:5efe` or `200:5efe` followed by
+// an IPv4 address (e.g. `fe80::5efe:10.0.0.1`).
+func (ipv6 IPv6Addr) IsISATAP() bool {
+	b := Uint128(ipv6.Address).Bytes()
+	return b[8]&^0x02 == 0 && b[9] == 0 && b[10] == 0x5e && b[11] == 0xfe
+}
+
+// EmbeddedIPv4 returns the IPv4 address embedded in a NAT64 address within the
+// Well-Known Prefix `64:ff9b::/96` (RFC 6052), a 6to4 address within
+// `2002::/16` (RFC 3056) or an ISATAP address (RFC 5214).  For example,
+// `64:ff9b::192.0.2.33`, `2002:c000:221::1` and `fe80::5efe:192.0.2.33` all
+// return `192.0.2.33`.  Use ExtractIPv4() for NAT64 addresses with a
+// network-specific prefix.  An error is returned if no IPv4 address is
+// embedded.
+func (ipv6 IPv6Addr) EmbeddedIPv4() (IPv4Addr, error) {
+	b := Uint128(ipv6.Address).Bytes()
+	var address uint32
+	switch {
+	case nat64WellKnownPrefix.ContainsAddress(ipv6.Address):
+		address = uint32(b[12])<<24 | uint32(b[13])<<16 | uint32(b[14])<<8 | uint32(b[15])
+	case sixToFourPrefix.ContainsAddress(ipv6.Address):
+		address = uint32(b[2])<<24 | uint32(b[3])<<16 | uint32(b[4])<<8 | uint32(b[5])
+	case ipv6.IsISATAP():
+		address = uint32(b[12])<<24 | uint32(b[13])<<16 | uint32(b[14])<<8 | uint32(b[15])
+	default:
+		return IPv4Addr{}, fmt.Errorf("Unable to find an IPv4 address embedded in %s", ipv6.zonedIPString())
+	}
+
+	return IPv4Addr{
+		Address: IPv4Address(address),
+		Mask:    IPv4HostMask,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package sockaddr_test
+
+import (
+	"errors"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestEmbedIPv4(t *testing.T) {
+	tests := []struct {
+		prefix string
+		ipv4   string
+		output string
+		fail   bool
+	}{
+		// RFC 6052, section 2.4
+		{prefix: "2001:db8::/32", ipv4: "192.0.2.33", output: "2001:db8:c000:221::"},
+		{prefix: "2001:db8:100::/40", ipv4: "192.0.2.33", output: "2001:db8:1c0:2:21::"},
+		{prefix: "2001:db8:122::/48", ipv4: "192.0.2.33", output: "2001:db8:122:c000:2:2100::"},
+		{prefix: "2001:db8:122:300::/56", ipv4: "192.0.2.33", output: "2001:db8:122:3c0:0:221::"},
+		{prefix: "2001:db8:122:344::/64", ipv4: "192.0.2.33", output: "2001:db8:122:344:c0:2:2100:0"},
+		{prefix: "2001:db8:122:344::/96", ipv4: "192.0.2.33", output: "2001:db8:122:344::c000:221"},
+		{prefix: "64:ff9b::/96", ipv4: "192.0.2.33", output: "64:ff9b::c000:221"},
+		{prefix: "64:ff9b::/96", ipv4: "10.0.0.1:53", output: "[64:ff9b::a00:1]:53"},
+		{prefix: "2001:db8::1/32", ipv4: "192.0.2.33", output: "2001:db8:c000:221::"},
+		{prefix: "2001:db8:0:0:100::/96", ipv4: "192.0.2.33", fail: true},
+		{prefix: "2001:db8::/33", ipv4: "192.0.2.33", fail: true},
+		{prefix: "2001:db8::/128", ipv4: "192.0.2.33", fail: true},
+	}
+
+	for _, test := range tests {
+		prefix := sockaddr.MustIPv6Addr(test.prefix)
+		ipv4 := sockaddr.MustIPv4Addr(test.ipv4)
+		embedded, err := sockaddr.EmbedIPv4(prefix, ipv4)
+		if test.fail {
+			if err == nil {
+				t.Errorf("expected EmbedIPv4(%s, %s) to fail, received %s", test.prefix, test.ipv4, embedded)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EmbedIPv4(%s, %s): %v", test.prefix, test.ipv4, err)
+			continue
+		}
+		if embedded.String() != test.output {
+			t.Errorf("EmbedIPv4(%s, %s): expected %q, received %q", test.prefix, test.ipv4, test.output, embedded)
+		}
+
+		// Extracting with the prefix length of the prefix returns the original
+		// address.
+		embedded.Mask = prefix.Mask
+		extracted, err := sockaddr.ExtractIPv4(embedded)
+		if err != nil || extracted.String() != ipv4.String() {
+			t.Errorf("ExtractIPv4(%s): expected %s, received %s, %v", embedded, ipv4, extracted, err)
+		}
+	}
+
+	if _, err := sockaddr.EmbedIPv4(sockaddr.MustIPv6Addr("2001:db8::/33"), sockaddr.MustIPv4Addr("192.0.2.33")); !errors.Is(err, sockaddr.ErrInvalidMask) {
+		t.Errorf("expected a /33 prefix to fail with ErrInvalidMask, received %v", err)
+	}
+}
+
+func TestExtractIPv4(t *testing.T) {
+	tests := []struct {
+		input  string
+		output string
+		fail   bool
+	}{
+		{input: "64:ff9b::192.0.2.33", output: "192.0.2.33"},
+		{input: "2001:db8:c000:221::/32", output: "192.0.2.33"},
+		{input: "2001:db8:1c0:2:21::/40", output: "192.0.2.33"},
+		{input: "[64:ff9b::a00:1]:53", output: "10.0.0.1:53"},
+		{input: "2001:db8:1c0:2:21::/41", fail: true},
+		{input: "2001:db8:1c0:2:121::/40", fail: true},
+		{input: "2001:db8::100:0:c000:221", fail: true},
+	}
+
+	for _, test := range tests {
+		extracted, err := sockaddr.ExtractIPv4(sockaddr.MustIPv6Addr(test.input))
+		if test.fail {
+			if err == nil {
+				t.Errorf("expected ExtractIPv4(%s) to fail, received %s", test.input, extracted)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ExtractIPv4(%s): %v", test.input, err)
+			continue
+		}
+		if extracted.String() != test.output {
+			t.Errorf("ExtractIPv4(%s): expected %q, received %q", test.input, test.output, extracted)
+		}
+	}
+}
+
+func TestIPv6Addr_EmbeddedIPv4(t *testing.T) {
+	tests := []struct {
+		input  string
+		output string
+		isatap bool
+	}{
+		{input: "64:ff9b::192.0.2.33", output: "192.0.2.33"},
+		{input: "64:ff9b::/96", output: "0.0.0.0"},
+		{input: "2002:c000:221::1", output: "192.0.2.33"},
+		{input: "2002:a00:1:1::/64", output: "10.0.0.1"},
+		{input: "fe80::5efe:192.0.2.33%eth0", output: "192.0.2.33", isatap: true},
+		{input: "2001:db8::200:5efe:a00:1", output: "10.0.0.1", isatap: true},
+		{input: "64:ff9b:1::192.0.2.33"},
+		{input: "2001:db8::1"},
+		{input: "2001:db8::100:5efe:a00:1"},
+		{input: "::ffff:192.0.2.33"},
+	}
+
+	for _, test := range tests {
+		ipv6 := sockaddr.MustIPv6Addr(test.input)
+		if ipv6.IsISATAP() != test.isatap {
+			t.Errorf("expected IsISATAP() of %s to be %t", test.input, test.isatap)
+		}
+
+		ipv4, err := ipv6.EmbeddedIPv4()
+		if test.output == "" {
+			if err == nil {
+				t.Errorf("expected EmbeddedIPv4() of %s to fail, received %s", test.input, ipv4)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("EmbeddedIPv4() of %s: %v", test.input, err)
+			continue
+		}
+		if ipv4.String() != test.output {
+			t.Errorf("EmbeddedIPv4() of %s: expected %q, received %q", test.input, test.output, ipv4)
+		}
+		if attr := sockaddr.IPv6AddrAttr(ipv6, "embedded_ipv4"); attr != test.output {
+			t.Errorf("embedded_ipv4 of %s: expected %q, received %q", test.input, test.output, attr)
+		}
+	}
+}
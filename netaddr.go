@@ -0,0 +1,139 @@
+package sockaddr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+// FromNetAddr creates a SockAddr from a net.Addr, such as the value returned
+// by net.Conn.RemoteAddr() or net.Listener.Addr().  The supported types are:
+//
+//   - *net.TCPAddr and *net.UDPAddr, returned as a host IPv4Addr or IPv6Addr
+//     with the port and zone preserved.
+//   - *net.IPAddr, returned as a host IPv4Addr or IPv6Addr.
+//   - *net.IPNet, returned as an IPv4Addr or IPv6Addr with the mask preserved.
+//     The address is not masked.
+//   - *net.UnixAddr, returned as a UnixSock.  The path is used as is, so
+//     relative paths and abstract names are preserved.  Unnamed sockets are
+//     returned as a UnixSock with an empty path.
+//
+// NOTE: net.IP stores IPv4 addresses in their IPv4-mapped IPv6 form, so
+// IPv4-mapped IPv6 addresses (e.g. `::ffff:192.0.2.1`) are always returned as
+// an IPv4Addr.
+func FromNetAddr(addr net.Addr) (SockAddr, error) {
+	switch v := addr.(type) {
+	case *net.TCPAddr:
+		if v == nil {
+			break
+		}
+		return fromNetIP(v.IP, nil, IPPort(v.Port), v.Zone, addr)
+	case *net.UDPAddr:
+		if v == nil {
+			break
+		}
+		return fromNetIP(v.IP, nil, IPPort(v.Port), v.Zone, addr)
+	case *net.IPAddr:
+		if v == nil {
+			break
+		}
+		return fromNetIP(v.IP, nil, 0, v.Zone, addr)
+	case *net.IPNet:
+		if v == nil {
+			break
+		}
+		return fromNetIP(v.IP, v.Mask, 0, "", addr)
+	case *net.UnixAddr:
+		if v == nil {
+			break
+		}
+		if v.Name == "" {
+			return UnixSock{}, nil
+		}
+		return NewUnixSock(v.Name)
+	default:
+		return nil, fmt.Errorf("Unable to convert unsupported type %T to a SockAddr", addr)
+	}
+
+	return nil, fmt.Errorf("Unable to convert a nil %T to a SockAddr", addr)
+}
+
+// fromNetIP creates an IPv4Addr or IPv6Addr from the fields of a net.Addr.  A
+// nil mask is a host mask.
+func fromNetIP(ip net.IP, mask net.IPMask, port IPPort, zone string, addr net.Addr) (IPAddr, error) {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ipv4Mask := IPv4HostMask
+		switch len(mask) {
+		case 0:
+		case IPv4len:
+			ipv4Mask = IPv4Mask(binary.BigEndian.Uint32(mask))
+		case IPv6len:
+			ipv4Mask = IPv4Mask(binary.BigEndian.Uint32(mask[IPv6len-IPv4len:]))
+		default:
+			return nil, fmt.Errorf("Unable to convert %s to an IPv4Addr: %w %s", addr, ErrInvalidMask, mask)
+		}
+
+		return IPv4Addr{
+			Address: IPv4Address(binary.BigEndian.Uint32(ipv4)),
+			Mask:    ipv4Mask,
+			Port:    port,
+		}, nil
+	}
+
+	if len(ip) != IPv6len {
+		return nil, fmt.Errorf("Unable to convert %s to an IPAddr: invalid IP address", addr)
+	}
+
+	ipv6Mask := ipv6HostMask
+	switch len(mask) {
+	case 0:
+	case IPv6len:
+		ipv6Mask = IPv6Mask(netIPToUint128(net.IP(mask)))
+	default:
+		return nil, fmt.Errorf("Unable to convert %s to an IPv6Addr: %w %s", addr, ErrInvalidMask, mask)
+	}
+
+	return IPv6Addr{
+		Address: IPv6Address(netIPToUint128(ip)),
+		Mask:    ipv6Mask,
+		Port:    port,
+		Zone:    zone,
+	}, nil
+}
+
+// TCPAddr returns the IPv4Addr as a *net.TCPAddr.  The netmask is not
+// included.
+func (ipv4 IPv4Addr) TCPAddr() *net.TCPAddr {
+	return &net.TCPAddr{IP: ipv4.netIP4(), Port: int(ipv4.Port)}
+}
+
+// UDPAddr returns the IPv4Addr as a *net.UDPAddr.  The netmask is not
+// included.
+func (ipv4 IPv4Addr) UDPAddr() *net.UDPAddr {
+	return &net.UDPAddr{IP: ipv4.netIP4(), Port: int(ipv4.Port)}
+}
+
+// netIP4 returns the address of the IPv4Addr as a 4 byte net.IP.
+func (ipv4 IPv4Addr) netIP4() net.IP {
+	ip := make(net.IP, IPv4len)
+	binary.BigEndian.PutUint32(ip, uint32(ipv4.Address))
+	return ip
+}
+
+// TCPAddr returns the IPv6Addr as a *net.TCPAddr.  The netmask is not
+// included.
+func (ipv6 IPv6Addr) TCPAddr() *net.TCPAddr {
+	return &net.TCPAddr{IP: *ipv6.NetIP(), Port: int(ipv6.Port), Zone: ipv6.Zone}
+}
+
+// UDPAddr returns the IPv6Addr as a *net.UDPAddr.  The netmask is not
+// included.
+func (ipv6 IPv6Addr) UDPAddr() *net.UDPAddr {
+	return &net.UDPAddr{IP: *ipv6.NetIP(), Port: int(ipv6.Port), Zone: ipv6.Zone}
+}
+
+// UnixAddr returns the UnixSock as a *net.UnixAddr with the `unix` network
+// type.
+func (us UnixSock) UnixAddr() *net.UnixAddr {
+	return &net.UnixAddr{Name: us.path, Net: "unix"}
+}
@@ -0,0 +1,137 @@
+package sockaddr_test
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestFromNetAddr(t *testing.T) {
+	tests := []struct {
+		name string
+		in   net.Addr
+		out  string
+		typ  sockaddr.SockAddrType
+		fail bool
+	}{
+		{name: "tcp4", in: &net.TCPAddr{IP: net.ParseIP("192.168.1.10"), Port: 8500}, out: "192.168.1.10:8500", typ: sockaddr.TypeIPv4},
+		{name: "tcp4 short", in: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1).To4(), Port: 80}, out: "10.0.0.1:80", typ: sockaddr.TypeIPv4},
+		{name: "tcp6 zone", in: &net.TCPAddr{IP: net.ParseIP("fe80::1"), Port: 80, Zone: "eth0"}, out: "[fe80::1%eth0]:80", typ: sockaddr.TypeIPv6},
+		{name: "udp6", in: &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 53}, out: "[2001:db8::1]:53", typ: sockaddr.TypeIPv6},
+		{name: "ip", in: &net.IPAddr{IP: net.ParseIP("fe80::1"), Zone: "en0"}, out: "fe80::1%en0", typ: sockaddr.TypeIPv6},
+		{name: "ipnet4", in: &net.IPNet{IP: net.IPv4(10, 1, 2, 3).To4(), Mask: net.CIDRMask(16, 32)}, out: "10.1.2.3/16", typ: sockaddr.TypeIPv4},
+		{name: "ipnet4 16 byte mask", in: &net.IPNet{IP: net.IPv4(10, 1, 2, 3), Mask: net.IPv4Mask(255, 255, 0, 0)}, out: "10.1.2.3/16", typ: sockaddr.TypeIPv4},
+		{name: "ipnet6", in: &net.IPNet{IP: net.ParseIP("2001:db8::"), Mask: net.CIDRMask(32, 128)}, out: "2001:db8::/32", typ: sockaddr.TypeIPv6},
+		{name: "unix relative", in: &net.UnixAddr{Name: "agent.sock", Net: "unix"}, out: `"agent.sock"`, typ: sockaddr.TypeUnix},
+		{name: "unix abstract", in: &net.UnixAddr{Name: "@agent", Net: "unixgram"}, out: `"@agent"`, typ: sockaddr.TypeUnix},
+		{name: "unix unnamed", in: &net.UnixAddr{Net: "unix"}, out: `""`, typ: sockaddr.TypeUnix},
+		{name: "invalid ip", in: &net.TCPAddr{IP: net.IP{1, 2, 3}}, fail: true},
+		{name: "invalid mask", in: &net.IPNet{IP: net.ParseIP("2001:db8::"), Mask: net.CIDRMask(8, 32)}, fail: true},
+		{name: "nil", in: nil, fail: true},
+		{name: "typed nil", in: (*net.TCPAddr)(nil), fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sa, err := sockaddr.FromNetAddr(test.in)
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected %v to fail, received %v", test.in, sa)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to convert %v: %v", test.in, err)
+			}
+
+			if sa.Type() != test.typ || sa.String() != test.out {
+				t.Fatalf("expected %s %s, received %s %s", test.typ, test.out, sa.Type(), sa)
+			}
+		})
+	}
+}
+
+func TestFromNetAddr_Listener(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("unable to listen: %v", err)
+	}
+	defer l.Close()
+
+	sa, err := sockaddr.FromNetAddr(l.Addr())
+	if err != nil {
+		t.Fatalf("unable to convert %v: %v", l.Addr(), err)
+	}
+
+	ipv4, ok := sa.(sockaddr.IPv4Addr)
+	if !ok || ipv4.TCPAddr().String() != l.Addr().String() {
+		t.Fatalf("expected %v, received %v", l.Addr(), sa)
+	}
+
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	ul, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unable to listen: %v", err)
+	}
+	defer ul.Close()
+
+	sa, err = sockaddr.FromNetAddr(ul.Addr())
+	if err != nil {
+		t.Fatalf("unable to convert %v: %v", ul.Addr(), err)
+	}
+
+	if !sa.Equal(sockaddr.MustUnixSock(path)) {
+		t.Fatalf("expected %q, received %v", path, sa)
+	}
+}
+
+func TestSockAddr_NetAddrAccessors(t *testing.T) {
+	ipv4 := sockaddr.MustIPv4Addr("10.0.0.1/8")
+	ipv4.Port = 8500
+	if got := ipv4.TCPAddr(); got.String() != "10.0.0.1:8500" || len(got.IP) != net.IPv4len {
+		t.Fatalf("unexpected TCPAddr %v", got)
+	}
+	if got := ipv4.UDPAddr(); got.String() != "10.0.0.1:8500" || got.Network() != "udp" {
+		t.Fatalf("unexpected UDPAddr %v", got)
+	}
+
+	ipv6 := sockaddr.MustIPv6Addr("[fe80::1%eth0]:53")
+	if got := ipv6.TCPAddr(); got.String() != "[fe80::1%eth0]:53" {
+		t.Fatalf("unexpected TCPAddr %v", got)
+	}
+	if got := ipv6.UDPAddr(); got.String() != "[fe80::1%eth0]:53" {
+		t.Fatalf("unexpected UDPAddr %v", got)
+	}
+
+	us := sockaddr.MustUnixSock("@agent")
+	if got := us.UnixAddr(); got.Name != "@agent" || got.Network() != "unix" {
+		t.Fatalf("unexpected UnixAddr %v", got)
+	}
+
+	for _, sa := range []sockaddr.SockAddr{ipv4, ipv6, us} {
+		var addr net.Addr
+		switch v := sa.(type) {
+		case sockaddr.IPv4Addr:
+			addr = v.UDPAddr()
+		case sockaddr.IPv6Addr:
+			addr = v.TCPAddr()
+		case sockaddr.UnixSock:
+			addr = v.UnixAddr()
+		}
+
+		back, err := sockaddr.FromNetAddr(addr)
+		if err != nil {
+			t.Fatalf("unable to convert %v: %v", addr, err)
+		}
+
+		expected := sa
+		if ipAddr, ok := sa.(sockaddr.IPAddr); ok {
+			expected = ipAddr.Host()
+		}
+		if !back.Equal(expected) {
+			t.Fatalf("expected %v, received %v", expected, back)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package sockaddr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// FromNetipAddr creates an IPAddr from a netip.Addr.  IPv4 addresses are
+// returned as an IPv4Addr with a /32 mask and IPv6 addresses are returned as
+// an IPv6Addr with a /128 mask.
+//
+// NOTE: IPv4-mapped IPv6 addresses (e.g. `::ffff:192.0.2.1`) are not unmapped
+// and are returned as an IPv6Addr.  Call netip.Addr.Unmap() before calling
+// FromNetipAddr() if an IPv4Addr is desired.  The zone of a scoped IPv6
+// address is preserved.
+func FromNetipAddr(addr netip.Addr) (IPAddr, error) {
+	switch {
+	case !addr.IsValid():
+		return nil, &ParseError{Input: addr.String(), Kind: KindSyntax, Family: TypeIP, Cause: errors.New("invalid netip.Addr")}
+	case addr.Is4():
+		return IPv4Addr{
+			Address: netipToIPv4Address(addr),
+			Mask:    IPv4HostMask,
+		}, nil
+	default:
+		return IPv6Addr{
+			Address: netipToIPv6Address(addr),
+			Mask:    ipv6HostMask,
+			Zone:    addr.Zone(),
+		}, nil
+	}
+}
+
+// FromNetipAddrPort creates an IPAddr from a netip.AddrPort.  The resulting
+// IPAddr is a host address (i.e. a /32 or a /128) with its port set.  See
+// FromNetipAddr() for the handling of IPv4-mapped and zoned addresses.
+func FromNetipAddrPort(addrPort netip.AddrPort) (IPAddr, error) {
+	ipAddr, err := FromNetipAddr(addrPort.Addr())
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := ipAddr.(type) {
+	case IPv4Addr:
+		v.Port = IPPort(addrPort.Port())
+		return v, nil
+	case IPv6Addr:
+		v.Port = IPPort(addrPort.Port())
+		return v, nil
+	default:
+		return nil, &ParseError{Input: addrPort.String(), Kind: KindFamily, Family: TypeIP, Cause: fmt.Errorf("unsupported type %T", ipAddr)}
+	}
+}
+
+// FromNetipPrefix creates an IPAddr from a netip.Prefix.  The address is not
+// masked, so `192.168.1.10/24` round-trips as `192.168.1.10/24` and not as
+// `192.168.1.0/24`.  Use netip.Prefix.Masked() beforehand to obtain the network
+// address.  IPv4-mapped IPv6 prefixes are returned as an IPv6Addr.
+func FromNetipPrefix(prefix netip.Prefix) (IPAddr, error) {
+	if !prefix.IsValid() {
+		return nil, &ParseError{Input: prefix.String(), Kind: KindSyntax, Family: TypeIP, Cause: errors.New("invalid netip.Prefix")}
+	}
+
+	addr := prefix.Addr()
+	if addr.Is4() {
+		mask := net.CIDRMask(prefix.Bits(), IPv4len*8)
+		return IPv4Addr{
+			Address: netipToIPv4Address(addr),
+			Mask:    IPv4Mask(binary.BigEndian.Uint32(mask)),
+		}, nil
+	}
+
+	return IPv6Addr{
+		Address: netipToIPv6Address(addr),
+		Mask:    IPv6Mask(uint128Mask(prefix.Bits())),
+	}, nil
+}
+
+// AddrPort returns the address and port of the IPv4Addr as a netip.AddrPort.
+// The network mask is not preserved, see Prefix().
+func (ipv4 IPv4Addr) AddrPort() netip.AddrPort {
+	return netip.AddrPortFrom(ipv4.NetipAddr(), uint16(ipv4.Port))
+}
+
+// NetipAddr returns the address of the IPv4Addr as a netip.Addr.
+func (ipv4 IPv4Addr) NetipAddr() netip.Addr {
+	var b [IPv4len]byte
+	binary.BigEndian.PutUint32(b[:], uint32(ipv4.Address))
+	return netip.AddrFrom4(b)
+}
+
+// Prefix returns the address and network mask of the IPv4Addr as a
+// netip.Prefix.  The host bits of the address are retained.  The port is not
+// preserved, see AddrPort().
+func (ipv4 IPv4Addr) Prefix() netip.Prefix {
+	return netip.PrefixFrom(ipv4.NetipAddr(), ipv4.Maskbits())
+}
+
+// AddrPort returns the address and port of the IPv6Addr as a netip.AddrPort.
+// The network mask is not preserved, see Prefix().
+func (ipv6 IPv6Addr) AddrPort() netip.AddrPort {
+	return netip.AddrPortFrom(ipv6.NetipAddr(), uint16(ipv6.Port))
+}
+
+// NetipAddr returns the address and zone of the IPv6Addr as a netip.Addr.
+// IPv4-mapped addresses are returned as-is and are not unmapped.
+func (ipv6 IPv6Addr) NetipAddr() netip.Addr {
+	return netip.AddrFrom16(Uint128(ipv6.Address).Bytes()).WithZone(ipv6.Zone)
+}
+
+// Prefix returns the address and network mask of the IPv6Addr as a
+// netip.Prefix.  The host bits of the address are retained.  The port and
+// zone are not preserved because a netip.Prefix can not carry either, see
+// AddrPort().
+func (ipv6 IPv6Addr) Prefix() netip.Prefix {
+	return netip.PrefixFrom(ipv6.NetipAddr(), ipv6.Maskbits())
+}
+
+// netipToIPv4Address converts an IPv4 netip.Addr to an IPv4Address.
+func netipToIPv4Address(addr netip.Addr) IPv4Address {
+	b := addr.As4()
+	return IPv4Address(binary.BigEndian.Uint32(b[:]))
+}
+
+// netipToIPv6Address converts a netip.Addr to an IPv6Address.  IPv4 addresses
+// are converted to their IPv4-mapped form.
+func netipToIPv6Address(addr netip.Addr) IPv6Address {
+	return IPv6Address(Uint128FromBytes(addr.As16()))
+}
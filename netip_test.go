@@ -0,0 +1,252 @@
+package sockaddr_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestFromNetipAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    netip.Addr
+		want     string
+		wantType sockaddr.SockAddrType
+		fail     bool
+	}{
+		{
+			name:     "ipv4",
+			input:    netip.MustParseAddr("192.0.2.1"),
+			want:     "192.0.2.1",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:     "ipv6",
+			input:    netip.MustParseAddr("2001:db8::1"),
+			want:     "2001:db8::1",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:     "ipv4-mapped ipv6",
+			input:    netip.MustParseAddr("::ffff:192.0.2.1"),
+			want:     "::ffff:192.0.2.1",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:     "zoned ipv6",
+			input:    netip.MustParseAddr("fe80::1%eth0"),
+			want:     "fe80::1%eth0",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:  "invalid",
+			input: netip.Addr{},
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ipAddr, err := sockaddr.FromNetipAddr(test.input)
+			if test.fail {
+				var pe *sockaddr.ParseError
+				if !errors.As(err, &pe) {
+					t.Fatalf("expected %q to fail with a ParseError, got %v (%v)", test.input, ipAddr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to convert %q: %v", test.input, err)
+			}
+
+			if ipAddr.Type() != test.wantType {
+				t.Errorf("wrong type: %s vs %s", ipAddr.Type(), test.wantType)
+			}
+			if ipAddr.String() != test.want {
+				t.Errorf("wrong string: %q vs %q", ipAddr.String(), test.want)
+			}
+			if got := ipAddr.NetipAddr(); got != test.input {
+				t.Errorf("failed round-trip: %v vs %v", got, test.input)
+			}
+		})
+	}
+}
+
+func TestFromNetipAddrPort(t *testing.T) {
+	tests := []struct {
+		name  string
+		input netip.AddrPort
+		want  string
+	}{
+		{
+			name:  "ipv4",
+			input: netip.MustParseAddrPort("192.0.2.1:8080"),
+			want:  "192.0.2.1:8080",
+		},
+		{
+			name:  "ipv6",
+			input: netip.MustParseAddrPort("[2001:db8::1]:443"),
+			want:  "[2001:db8::1]:443",
+		},
+		{
+			name:  "zoned ipv6",
+			input: netip.MustParseAddrPort("[fe80::1%eth0]:443"),
+			want:  "[fe80::1%eth0]:443",
+		},
+		{
+			name:  "ipv4 without port",
+			input: netip.MustParseAddrPort("192.0.2.1:0"),
+			want:  "192.0.2.1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ipAddr, err := sockaddr.FromNetipAddrPort(test.input)
+			if err != nil {
+				t.Fatalf("unable to convert %q: %v", test.input, err)
+			}
+
+			if ipAddr.String() != test.want {
+				t.Errorf("wrong string: %q vs %q", ipAddr.String(), test.want)
+			}
+			if got := ipAddr.AddrPort(); got != test.input {
+				t.Errorf("failed round-trip: %v vs %v", got, test.input)
+			}
+		})
+	}
+
+	var pe *sockaddr.ParseError
+	if ipAddr, err := sockaddr.FromNetipAddrPort(netip.AddrPort{}); !errors.As(err, &pe) {
+		t.Fatalf("expected an invalid netip.AddrPort to fail with a ParseError, got %v (%v)", ipAddr, err)
+	}
+}
+
+func TestFromNetipPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       netip.Prefix
+		want        string
+		wantNetwork string
+		fail        bool
+	}{
+		{
+			name:        "ipv4 network",
+			input:       netip.MustParsePrefix("10.0.0.0/8"),
+			want:        "10.0.0.0/8",
+			wantNetwork: "10.0.0.0/8",
+		},
+		{
+			name:        "ipv4 host bits retained",
+			input:       netip.MustParsePrefix("192.168.1.10/24"),
+			want:        "192.168.1.10/24",
+			wantNetwork: "192.168.1.0/24",
+		},
+		{
+			name:        "ipv4 host",
+			input:       netip.MustParsePrefix("192.168.1.10/32"),
+			want:        "192.168.1.10",
+			wantNetwork: "192.168.1.10",
+		},
+		{
+			name:        "ipv6 host bits retained",
+			input:       netip.MustParsePrefix("2001:db8::3/64"),
+			want:        "2001:db8::3/64",
+			wantNetwork: "2001:db8::/64",
+		},
+		{
+			name:        "ipv4-mapped ipv6",
+			input:       netip.MustParsePrefix("::ffff:10.0.0.0/104"),
+			want:        "::ffff:10.0.0.0/104",
+			wantNetwork: "::ffff:10.0.0.0/104",
+		},
+		{
+			name:  "invalid",
+			input: netip.Prefix{},
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ipAddr, err := sockaddr.FromNetipPrefix(test.input)
+			if test.fail {
+				var pe *sockaddr.ParseError
+				if !errors.As(err, &pe) {
+					t.Fatalf("expected %q to fail with a ParseError, got %v (%v)", test.input, ipAddr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to convert %q: %v", test.input, err)
+			}
+
+			if ipAddr.String() != test.want {
+				t.Errorf("wrong string: %q vs %q", ipAddr.String(), test.want)
+			}
+			if ipAddr.Network().String() != test.wantNetwork {
+				t.Errorf("wrong network: %q vs %q", ipAddr.Network().String(), test.wantNetwork)
+			}
+			if got := ipAddr.Prefix(); got != test.input {
+				t.Errorf("failed round-trip: %v vs %v", got, test.input)
+			}
+		})
+	}
+}
+
+func TestIPAddr_Netip(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantAddr     string
+		wantAddrPort string
+		wantPrefix   string
+	}{
+		{
+			input:        "192.168.0.1",
+			wantAddr:     "192.168.0.1",
+			wantAddrPort: "192.168.0.1:0",
+			wantPrefix:   "192.168.0.1/32",
+		},
+		{
+			input:        "192.168.0.1:80",
+			wantAddr:     "192.168.0.1",
+			wantAddrPort: "192.168.0.1:80",
+			wantPrefix:   "192.168.0.1/32",
+		},
+		{
+			input:        "172.16.1.3/12",
+			wantAddr:     "172.16.1.3",
+			wantAddrPort: "172.16.1.3:0",
+			wantPrefix:   "172.16.1.3/12",
+		},
+		{
+			input:        "[2001:db8::1]:8080",
+			wantAddr:     "2001:db8::1",
+			wantAddrPort: "[2001:db8::1]:8080",
+			wantPrefix:   "2001:db8::1/128",
+		},
+		{
+			input:        "2001:db8::1/48",
+			wantAddr:     "2001:db8::1",
+			wantAddrPort: "[2001:db8::1]:0",
+			wantPrefix:   "2001:db8::1/48",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ipAddr := sockaddr.MustIPAddr(test.input)
+			if got := ipAddr.NetipAddr().String(); got != test.wantAddr {
+				t.Errorf("wrong netip.Addr: %q vs %q", got, test.wantAddr)
+			}
+			if got := ipAddr.AddrPort().String(); got != test.wantAddrPort {
+				t.Errorf("wrong netip.AddrPort: %q vs %q", got, test.wantAddrPort)
+			}
+			if got := ipAddr.Prefix().String(); got != test.wantPrefix {
+				t.Errorf("wrong netip.Prefix: %q vs %q", got, test.wantPrefix)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package sockaddr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// PortPolicy controls whether ParseSockAddr() and ParseIPAddr() accept input
+// with a port number.
+type PortPolicy int
+
+const (
+	// PortAllowed accepts input with or without a port number.
+	PortAllowed PortPolicy = iota
+
+	// PortRequired rejects IP addresses and hostnames without a port number
+	// (e.g. `10.0.0.1` or `[2001:db8::1]` instead of `10.0.0.1:80` or
+	// `[2001:db8::1]:80`).  CIDR blocks can never have a port number.
+	PortRequired
+
+	// PortForbidden rejects IP addresses and hostnames with a port number,
+	// including a port number of zero.
+	PortForbidden
+)
+
+// ParseOptions controls how ParseSockAddr() and ParseIPAddr() interpret their
+// input.  The zero value parses input exactly like NewSockAddr() and
+// NewIPAddr().
+type ParseOptions struct {
+	// KeepMapped returns IPv4-mapped IPv6 addresses (e.g. `::ffff:10.0.0.1`
+	// or `::ffff:10.0.0.0/104`) as an IPv6Addr instead of converting them to
+	// an IPv4Addr.  See IPv6Addr.Unmap() and IPv4Addr.ToMapped().
+	KeepMapped bool
+
+	// AllowHostname returns input that is neither an IP address nor a UNIX
+	// socket path as a Hostname (e.g. `consul.service:8500`).  See
+	// Hostname.Resolve().
+	AllowHostname bool
+
+	// Strict disables the heuristics used by NewSockAddr() and NewIPAddr()
+	// and rejects any input that is not unambiguously an IP address, UNIX
+	// socket or, if AllowHostname is set, a hostname.  In strict mode:
+	//
+	//   - IP addresses must be literals, hostnames such as `localhost:80` are
+	//     never resolved through DNS.
+	//   - Netmasks must be a decimal prefix length that is valid for the
+	//     address family.  Trailing hex netmasks (e.g. `192.168.3.51/00ffffff`,
+	//     emitted by the Barracuda VPN client) and zero-padded prefix lengths
+	//     or ports (e.g. `10.0.0.0/08` or `10.0.0.1:080`) are rejected.
+	//   - Unless KeepMapped is set, IPv4-mapped networks (e.g.
+	//     `::ffff:10.0.0.0/104`) are rejected instead of being rewritten to an
+	//     IPv4 network.
+	//   - UNIX socket paths must be absolute (e.g. `/tmp/foo.sock`), explicitly
+	//     relative (e.g. `./foo.sock` or `../foo.sock`), or abstract (e.g.
+	//     `@foo.sock`).  A `/` elsewhere in the input (e.g. `256.0.0.0/-1`)
+	//     does not make it a UNIX socket.
+	Strict bool
+
+	// Families is the set of SockAddr types that may be returned (e.g.
+	// `TypeIP|TypeUnix`).  The zero value allows every type.  Hostnames must
+	// also be enabled with AllowHostname.
+	Families SockAddrType
+
+	// Port controls whether input may or must include a port number.  Port
+	// numbers do not apply to UNIX sockets.
+	Port PortPolicy
+
+	// LegacyIPv4 accepts IPv4 addresses in the legacy forms of inet_aton(3)
+	// (e.g. `10.1`, `0x0a000001`, `012.0.0.1` or `167772161`) and normalizes
+	// them, see ParseIPv4Legacy().  Input that is valid in either form is
+	// read the inet_aton(3) way (e.g. `012.0.0.1` is `10.0.0.1`).  Warnings
+	// about ambiguous octal numbers are discarded, unless Strict is also set:
+	// then ambiguous octal numbers and zero-padded prefix lengths or ports are
+	// rejected with a ParseError wrapping ErrAmbiguousOctal or ErrInvalidMask.
+	// Call ParseIPv4Legacy() directly to retrieve the warnings.  Because a
+	// plain integer is a valid IPv4 address in this form, it takes precedence
+	// over hostnames.
+	LegacyIPv4 bool
+}
+
+// ParseSockAddr creates a new SockAddr from the string according to opts.
+// See NewSockAddr() for the heuristics used to detect a UnixSock when
+// opts.Strict is not set.
+func ParseSockAddr(s string, opts ParseOptions) (SockAddr, error) {
+	sa, err := parseSockAddr(s, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.check(s, sa); err != nil {
+		return nil, err
+	}
+
+	return sa, nil
+}
+
+// ParseIPAddr creates a new IPAddr from the string according to opts.
+func ParseIPAddr(s string, opts ParseOptions) (IPAddr, error) {
+	ipAddr, err := parseIPAddr(s, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.check(s, ipAddr); err != nil {
+		return nil, err
+	}
+
+	return ipAddr, nil
+}
+
+// parseSockAddr is ParseSockAddr() without the Families and Port checks.
+func parseSockAddr(s string, opts ParseOptions) (SockAddr, error) {
+	ipAddr, ipErr := parseIPAddr(s, opts)
+	if ipErr == nil {
+		return ipAddr, nil
+	}
+
+	// An IP address or network followed by a port range (e.g.
+	// `10.0.0.0/8:8000-8100`)
+	portRange, rangeErr := parseIPPortRange(s, opts)
+	if rangeErr == nil {
+		return portRange, nil
+	}
+
+	// A range of IP addresses (e.g. `10.0.0.10-10.0.0.200`)
+	ipRange, ipRangeErr := parseIPRange(s, opts)
+	if ipRangeErr == nil {
+		return ipRange, nil
+	}
+
+	var unixErr error
+	if isUnixSockPath(s, opts.Strict) {
+		unixSock, err := NewUnixSock(s)
+		if err == nil {
+			return unixSock, nil
+		}
+		unixErr = err
+	}
+
+	if opts.AllowHostname {
+		hostname, err := NewHostname(s)
+		if err == nil {
+			return hostname, nil
+		}
+	}
+
+	// Report the most specific error: an IP address with e.g. an invalid
+	// mask, an invalid port range, an invalid address range, then an
+	// invalid UNIX socket path.
+	var pe *ParseError
+	if errors.As(ipErr, &pe) && pe.Kind != KindSyntax && pe.Kind != KindFamily {
+		return nil, ipErr
+	}
+	if errors.As(rangeErr, &pe) && pe.Kind != KindSyntax && pe.Kind != KindFamily {
+		return nil, rangeErr
+	}
+	if errors.As(ipRangeErr, &pe) && pe.Kind != KindSyntax && pe.Kind != KindFamily {
+		return nil, ipRangeErr
+	}
+	if unixErr != nil {
+		return nil, unixErr
+	}
+
+	return nil, &ParseError{Input: s, Kind: KindSyntax, Family: TypeUnknown}
+}
+
+// parseIPAddr is ParseIPAddr() without the Families and Port checks.
+func parseIPAddr(s string, opts ParseOptions) (IPAddr, error) {
+	if opts.LegacyIPv4 {
+		if ipv4, warnings, err := ParseIPv4Legacy(s); err == nil {
+			if opts.Strict {
+				if err := checkStrictIPv4Legacy(s, warnings); err != nil {
+					return nil, err
+				}
+			}
+			return ipv4, nil
+		}
+	}
+
+	if opts.Strict {
+		if err := checkStrictIPAddr(s, opts.KeepMapped); err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.KeepMapped {
+		return NewIPAddr(s)
+	}
+
+	// IPv6 parsing must come first, otherwise NewIPv4Addr() converts
+	// IPv4-mapped addresses to an IPv4Addr.  NewIPv6Addr() rejects all
+	// IPv4 inputs.
+	ipv6Addr, ipv6Err := NewIPv6Addr(s)
+	if ipv6Err == nil {
+		return ipv6Addr, nil
+	}
+
+	ipv4Addr, ipv4Err := NewIPv4Addr(s)
+	if ipv4Err == nil {
+		return ipv4Addr, nil
+	}
+
+	return nil, ipAddrParseError(s, ipv6Err, ipv4Err)
+}
+
+// isUnixSockPath returns true if s looks like the path of a UNIX socket.
+func isUnixSockPath(s string, strict bool) bool {
+	if len(s) < 2 {
+		return false
+	}
+
+	if strict {
+		switch {
+		case s[0] == '/', s[0] == '@', s[0] == '\x00':
+			return true
+		case strings.HasPrefix(s, "./"), strings.HasPrefix(s, "../"):
+			return true
+		}
+		return false
+	}
+
+	// Check to make sure the string begins with either a '.', '/', or the
+	// '@' or NUL prefix of an abstract socket, or contains a '/'.
+	return strings.IndexAny(s[0:1], "./@\x00") != -1 || strings.IndexByte(s, '/') != -1
+}
+
+// checkStrictIPAddr returns an error if s is not a literal IPv4 or IPv6
+// address, optionally followed by either a decimal prefix length or a port
+// number.  IPv4-mapped networks are rejected unless keepMapped is set, as they
+// would otherwise be rewritten to an IPv4 network.
+func checkStrictIPAddr(s string, keepMapped bool) error {
+	host, portStr, hasPort := s, "", false
+	switch {
+	case strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]"):
+		host = s[1 : len(s)-1]
+	case strings.HasPrefix(s, "["), strings.Count(s, ":") == 1:
+		var err error
+		host, portStr, err = net.SplitHostPort(s)
+		if err != nil {
+			return &ParseError{Input: s, Kind: KindSyntax, Family: TypeIP, Cause: err}
+		}
+		hasPort = true
+	}
+
+	addr, maskStr, hasMask := host, "", false
+	if i := strings.IndexByte(host, '/'); i != -1 {
+		addr, maskStr, hasMask = host[:i], host[i+1:], true
+	}
+
+	if hasPort && hasMask {
+		return &ParseError{Input: s, Kind: KindPort, Family: TypeIP, Cause: errors.New("a CIDR block can not have a port")}
+	}
+
+	ipStr := addr
+	if i := strings.IndexByte(addr, '%'); i != -1 {
+		ipStr = addr[:i]
+	}
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return &ParseError{Input: s, Kind: KindSyntax, Family: TypeIP, Cause: fmt.Errorf("%+q is not a literal IP address", addr)}
+	}
+
+	if hasMask {
+		maxBits := IPv6len * 8
+		if strings.IndexByte(ipStr, ':') == -1 {
+			maxBits = IPv4len * 8
+		}
+
+		bits, err := strconv.ParseUint(maskStr, 10, 8)
+		if err != nil || int(bits) > maxBits || hasLeadingZero(maskStr) {
+			return &ParseError{Input: s, Kind: KindMask, Family: TypeIP, Cause: fmt.Errorf("%w: prefix length %+q must be between 0 and %d", ErrInvalidMask, maskStr, maxBits)}
+		}
+
+		if !keepMapped && maxBits == IPv6len*8 && ip.To4() != nil {
+			return &ParseError{Input: s, Kind: KindMask, Family: TypeIP, Cause: fmt.Errorf("%w: IPv4-mapped network %+q would be rewritten to an IPv4 network, see KeepMapped", ErrInvalidMask, addr+"/"+maskStr)}
+		}
+	}
+
+	if hasPort {
+		if _, err := strconv.ParseUint(portStr, 10, 16); err != nil || hasLeadingZero(portStr) {
+			return &ParseError{Input: s, Kind: KindPort, Family: TypeIP, Cause: fmt.Errorf("invalid port %+q", portStr)}
+		}
+	}
+
+	return nil
+}
+
+// hasLeadingZero returns true if the decimal number s is zero-padded (e.g.
+// `08`).
+func hasLeadingZero(s string) bool {
+	return len(s) > 1 && s[0] == '0'
+}
+
+// check returns an error if sa, parsed from s, is not permitted by the
+// Families and Port options.
+func (opts ParseOptions) check(s string, sa SockAddr) error {
+	if opts.Families != 0 && sa.Type()&opts.Families == 0 {
+		return &ParseError{Input: s, Kind: KindFamily, Family: sa.Type(), Cause: errors.New("address family not allowed")}
+	}
+
+	if opts.Port == PortAllowed || sa.Type() == TypeUnix {
+		return nil
+	}
+
+	switch hasPort := inputHasPort(s, sa); {
+	case opts.Port == PortRequired && !hasPort:
+		return &ParseError{Input: s, Kind: KindPort, Family: sa.Type(), Cause: errors.New("a port is required")}
+	case opts.Port == PortForbidden && hasPort:
+		return &ParseError{Input: s, Kind: KindPort, Family: sa.Type(), Cause: errors.New("a port is not allowed")}
+	}
+
+	return nil
+}
+
+// inputHasPort returns true if s, which was successfully parsed as sa,
+// included a port number.  A port number of zero is not detectable from sa
+// alone, so s is inspected for the port separator.
+func inputHasPort(s string, sa SockAddr) bool {
+	switch sa.Type() {
+	case TypeIPv6:
+		return strings.HasPrefix(s, "[") && strings.Contains(s, "]:")
+	case TypeIPv4:
+		// IPv4Addrs parsed from IPv4-mapped IPv6 input
+		if strings.HasPrefix(s, "[") {
+			return strings.Contains(s, "]:")
+		}
+		return strings.Count(s, ":") == 1
+	case TypeHostname:
+		return strings.IndexByte(s, ':') != -1
+	case TypeIPPortRange:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,491 @@
+package sockaddr_test
+
+import (
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestParseSockAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     sockaddr.ParseOptions
+		want     string
+		wantType sockaddr.SockAddrType
+		fail     bool
+	}{
+		{
+			name:     "mapped default",
+			input:    "::ffff:10.0.0.1",
+			want:     "10.0.0.1",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:     "mapped CIDR default",
+			input:    "::ffff:10.0.0.0/104",
+			want:     "10.0.0.0/8",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:     "mapped kept",
+			input:    "::ffff:10.0.0.1",
+			opts:     sockaddr.ParseOptions{KeepMapped: true},
+			want:     "::ffff:10.0.0.1",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:     "mapped CIDR kept",
+			input:    "::ffff:10.0.0.0/104",
+			opts:     sockaddr.ParseOptions{KeepMapped: true},
+			want:     "::ffff:10.0.0.0/104",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:     "mapped with port kept",
+			input:    "[::ffff:10.0.0.1]:80",
+			opts:     sockaddr.ParseOptions{KeepMapped: true},
+			want:     "[::ffff:10.0.0.1]:80",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:     "ipv4 with keep mapped",
+			input:    "10.0.0.1:80",
+			opts:     sockaddr.ParseOptions{KeepMapped: true},
+			want:     "10.0.0.1:80",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:     "unix with keep mapped",
+			input:    "/tmp/foo.sock",
+			opts:     sockaddr.ParseOptions{KeepMapped: true},
+			want:     `"/tmp/foo.sock"`,
+			wantType: sockaddr.TypeUnix,
+		},
+		{
+			name:     "hostname allowed",
+			input:    "consul.service:8500",
+			opts:     sockaddr.ParseOptions{AllowHostname: true},
+			want:     "consul.service:8500",
+			wantType: sockaddr.TypeHostname,
+		},
+		{
+			name:     "ipv4 with hostname allowed",
+			input:    "10.0.0.1:80",
+			opts:     sockaddr.ParseOptions{AllowHostname: true},
+			want:     "10.0.0.1:80",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:  "hostname not allowed",
+			input: "consul.service:8500",
+			fail:  true,
+		},
+		{
+			name:  "invalid hostname",
+			input: "bad host:80",
+			opts:  sockaddr.ParseOptions{AllowHostname: true},
+			fail:  true,
+		},
+		{
+			name:     "abstract unix",
+			input:    "@myapp.sock",
+			want:     `"@myapp.sock"`,
+			wantType: sockaddr.TypeUnix,
+		},
+		{
+			name:  "empty abstract unix",
+			input: "@",
+			fail:  true,
+		},
+		{
+			name:  "invalid",
+			input: "a",
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sa, err := sockaddr.ParseSockAddr(test.input, test.opts)
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected %q to fail, got %v", test.input, sa)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if sa.Type() != test.wantType {
+				t.Errorf("wrong type: %s vs %s", sa.Type(), test.wantType)
+			}
+			if sa.String() != test.want {
+				t.Errorf("wrong string: %q vs %q", sa.String(), test.want)
+			}
+
+			if test.wantType&sockaddr.TypeIP == 0 {
+				return
+			}
+
+			ipAddr, err := sockaddr.ParseIPAddr(test.input, test.opts)
+			if err != nil {
+				t.Fatalf("unable to parse %q as an IPAddr: %v", test.input, err)
+			}
+			if !ipAddr.Equal(sa) {
+				t.Errorf("ParseIPAddr mismatch: %q vs %q", ipAddr, sa)
+			}
+		})
+	}
+}
+
+func TestParseSockAddr_Strict(t *testing.T) {
+	strict := sockaddr.ParseOptions{Strict: true}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     sockaddr.ParseOptions
+		want     string
+		wantType sockaddr.SockAddrType
+		fail     bool
+	}{
+		{
+			name:     "ipv4",
+			input:    "10.0.0.1",
+			opts:     strict,
+			want:     "10.0.0.1",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:     "ipv4 port",
+			input:    "10.0.0.1:80",
+			opts:     strict,
+			want:     "10.0.0.1:80",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:     "ipv4 cidr",
+			input:    "10.0.0.0/8",
+			opts:     strict,
+			want:     "10.0.0.0/8",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:     "ipv6 cidr",
+			input:    "2001:db8::/32",
+			opts:     strict,
+			want:     "2001:db8::/32",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:     "ipv6 brackets",
+			input:    "[2001:db8::1]",
+			opts:     strict,
+			want:     "2001:db8::1",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:     "ipv6 zone port",
+			input:    "[fe80::1%eth0]:80",
+			opts:     strict,
+			want:     "[fe80::1%eth0]:80",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:     "mapped cidr keep mapped",
+			input:    "::ffff:10.0.0.0/104",
+			opts:     sockaddr.ParseOptions{Strict: true, KeepMapped: true},
+			want:     "::ffff:10.0.0.0/104",
+			wantType: sockaddr.TypeIPv6,
+		},
+		{
+			name:     "mapped address",
+			input:    "::ffff:10.0.0.1",
+			opts:     strict,
+			want:     "10.0.0.1",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:     "zero port",
+			input:    "10.0.0.1:0",
+			opts:     strict,
+			want:     "10.0.0.1",
+			wantType: sockaddr.TypeIPv4,
+		},
+		{
+			name:     "absolute unix",
+			input:    "/tmp/foo.sock",
+			opts:     strict,
+			want:     `"/tmp/foo.sock"`,
+			wantType: sockaddr.TypeUnix,
+		},
+		{
+			name:     "relative unix",
+			input:    "./foo.sock",
+			opts:     strict,
+			want:     `"./foo.sock"`,
+			wantType: sockaddr.TypeUnix,
+		},
+		{
+			name:     "abstract unix",
+			input:    "@foo.sock",
+			opts:     strict,
+			want:     `"@foo.sock"`,
+			wantType: sockaddr.TypeUnix,
+		},
+		{
+			name:     "hostname",
+			input:    "localhost:80",
+			opts:     sockaddr.ParseOptions{Strict: true, AllowHostname: true},
+			want:     "localhost:80",
+			wantType: sockaddr.TypeHostname,
+		},
+		{
+			name:     "port range",
+			input:    "[2001:db8::/32]:8000-8100",
+			opts:     strict,
+			want:     "[2001:db8::/32]:8000-8100",
+			wantType: sockaddr.TypeIPPortRange,
+		},
+		{
+			name:  "port range hex netmask",
+			input: "192.168.3.51/00ffffff:80-90",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "hex netmask",
+			input: "192.168.3.51/00ffffff",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "ipv6 sized ipv4 mask",
+			input: "10.0.0.0/104",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "mapped cidr",
+			input: "::ffff:10.0.0.0/104",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "mapped host cidr",
+			input: "::ffff:10.0.0.1/128",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "zero-padded mask",
+			input: "10.0.0.1/08",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "zero-padded port",
+			input: "10.0.0.1:080",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "zero-padded port range",
+			input: "10.0.0.1:080-090",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "ipv4 mask too long",
+			input: "10.0.0.0/33",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "ipv6 mask too long",
+			input: "2001:db8::/129",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "negative mask",
+			input: "256.0.0.0/-1",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "signed mask",
+			input: "10.0.0.0/+8",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "relative path without dot",
+			input: "foo/bar",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "hostname not resolved",
+			input: "localhost:80",
+			opts:  strict,
+			fail:  true,
+		},
+		{
+			name:  "invalid port",
+			input: "10.0.0.1:65536",
+			opts:  strict,
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sa, err := sockaddr.ParseSockAddr(test.input, test.opts)
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected %q to fail, got %v", test.input, sa)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if sa.Type() != test.wantType {
+				t.Errorf("wrong type: %s vs %s", sa.Type(), test.wantType)
+			}
+			if sa.String() != test.want {
+				t.Errorf("wrong string: %q vs %q", sa.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestParseSockAddr_Constraints(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  sockaddr.ParseOptions
+		fail  bool
+	}{
+		{
+			name:  "ipv4 allowed",
+			input: "10.0.0.1",
+			opts:  sockaddr.ParseOptions{Families: sockaddr.TypeIP},
+		},
+		{
+			name:  "ipv4 not allowed",
+			input: "10.0.0.1",
+			opts:  sockaddr.ParseOptions{Families: sockaddr.TypeIPv6 | sockaddr.TypeUnix},
+			fail:  true,
+		},
+		{
+			name:  "mapped not allowed",
+			input: "::ffff:10.0.0.1",
+			opts:  sockaddr.ParseOptions{Families: sockaddr.TypeIPv6},
+			fail:  true,
+		},
+		{
+			name:  "mapped kept",
+			input: "::ffff:10.0.0.1",
+			opts:  sockaddr.ParseOptions{Families: sockaddr.TypeIPv6, KeepMapped: true},
+		},
+		{
+			name:  "unix not allowed",
+			input: "/tmp/foo.sock",
+			opts:  sockaddr.ParseOptions{Families: sockaddr.TypeIP},
+			fail:  true,
+		},
+		{
+			name:  "port required",
+			input: "10.0.0.1:80",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortRequired},
+		},
+		{
+			name:  "port required zero",
+			input: "[2001:db8::1]:0",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortRequired},
+		},
+		{
+			name:  "port required missing",
+			input: "10.0.0.1",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortRequired},
+			fail:  true,
+		},
+		{
+			name:  "port required cidr",
+			input: "10.0.0.0/8",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortRequired},
+			fail:  true,
+		},
+		{
+			name:  "port required hostname",
+			input: "consul.service",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortRequired, AllowHostname: true},
+			fail:  true,
+		},
+		{
+			name:  "port required unix",
+			input: "/tmp/foo.sock",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortRequired},
+		},
+		{
+			name:  "port forbidden",
+			input: "2001:db8::1",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortForbidden},
+		},
+		{
+			name:  "port forbidden present",
+			input: "[2001:db8::1]:80",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortForbidden},
+			fail:  true,
+		},
+		{
+			name:  "port forbidden zero",
+			input: "10.0.0.1:0",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortForbidden},
+			fail:  true,
+		},
+		{
+			name:  "port forbidden range",
+			input: "10.0.0.0/8:8000-8100",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortForbidden},
+			fail:  true,
+		},
+		{
+			name:  "port range not allowed",
+			input: "10.0.0.0/8:8000-8100",
+			opts:  sockaddr.ParseOptions{Families: sockaddr.TypeIP},
+			fail:  true,
+		},
+		{
+			name:  "port forbidden mapped",
+			input: "[::ffff:10.0.0.1]:80",
+			opts:  sockaddr.ParseOptions{Port: sockaddr.PortForbidden},
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sa, err := sockaddr.ParseSockAddr(test.input, test.opts)
+			switch {
+			case test.fail && err == nil:
+				t.Fatalf("expected %q to fail, got %v", test.input, sa)
+			case !test.fail && err != nil:
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if sa == nil || sa.Type()&sockaddr.TypeIP == 0 {
+				return
+			}
+
+			if _, err := sockaddr.ParseIPAddr(test.input, test.opts); err != nil {
+				t.Errorf("ParseIPAddr mismatch: %v", err)
+			}
+		})
+	}
+}
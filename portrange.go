@@ -0,0 +1,340 @@
+package sockaddr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IPPortRange is a SockAddr that contains an IPv4 or IPv6 address or network
+// and an inclusive range of ports (e.g. `10.0.0.0/8:8000-8100` or
+// `[2001:db8::/32]:8000-8100`), as commonly found in firewall and ACL
+// configurations.  The port of Addr is always zero.
+type IPPortRange struct {
+	SockAddr
+	Addr IPAddr
+	Low  IPPort
+	High IPPort
+}
+
+// portRangeAttrMap is a map of the IPPortRange type-specific attributes.
+var portRangeAttrMap map[AttrName]func(IPPortRange) string
+var portRangeAttrs []AttrName
+
+func init() {
+	portRangeAttrInit()
+}
+
+// NewIPPortRange creates an IPPortRange from a string.  String must be in the
+// form of an IPv4 address or CIDR followed by a port range (e.g.
+// `10.0.0.1:8000-8100` or `10.0.0.0/8:8000-8100`), or a bracketed IPv6 address
+// or CIDR followed by a port range (e.g. `[2001:db8::/32]:8000-8100`).  A
+// single port (e.g. `10.0.0.0/8:80`) is a range of one port.
+func NewIPPortRange(s string) (IPPortRange, error) {
+	return parseIPPortRange(s, ParseOptions{})
+}
+
+// MustIPPortRange is a helper method that must return an IPPortRange or panic
+// on invalid input.
+func MustIPPortRange(addr string) IPPortRange {
+	r, err := NewIPPortRange(addr)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to create an IPPortRange from %+q: %v", addr, err))
+	}
+	return r
+}
+
+// ParsePortRange parses an inclusive port range (e.g. `8000-8100`) or a single
+// port (e.g. `80`).
+func ParsePortRange(s string) (low, high IPPort, err error) {
+	lowStr, highStr := s, s
+	if i := strings.IndexByte(s, '-'); i != -1 {
+		lowStr, highStr = s[:i], s[i+1:]
+	}
+
+	l, err := strconv.ParseUint(lowStr, 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port %+q in port range %+q", lowStr, s)
+	}
+
+	h, err := strconv.ParseUint(highStr, 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port %+q in port range %+q", highStr, s)
+	}
+
+	if l > h {
+		return 0, 0, fmt.Errorf("invalid port range %+q: %d is greater than %d", s, l, h)
+	}
+
+	return IPPort(l), IPPort(h), nil
+}
+
+// parseIPPortRange creates an IPPortRange from a string, parsing the address
+// according to opts.
+func parseIPPortRange(s string, opts ParseOptions) (IPPortRange, error) {
+	var addrStr, rangeStr string
+	if strings.HasPrefix(s, "[") {
+		i := strings.Index(s, "]:")
+		if i == -1 {
+			return IPPortRange{}, &ParseError{Input: s, Kind: KindSyntax, Family: TypeIPPortRange, Cause: errors.New("missing port range")}
+		}
+		addrStr, rangeStr = s[1:i], s[i+2:]
+	} else {
+		i := strings.LastIndexByte(s, ':')
+		if i == -1 {
+			return IPPortRange{}, &ParseError{Input: s, Kind: KindSyntax, Family: TypeIPPortRange, Cause: errors.New("missing port range")}
+		}
+		addrStr, rangeStr = s[:i], s[i+1:]
+
+		if strings.IndexByte(addrStr, ':') != -1 {
+			return IPPortRange{}, &ParseError{Input: s, Kind: KindSyntax, Family: TypeIPPortRange, Cause: errors.New("IPv6 addresses must be enclosed in brackets")}
+		}
+	}
+
+	ipAddr, err := parseIPAddr(addrStr, opts)
+	if err != nil {
+		kind := KindSyntax
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			kind = pe.Kind
+		}
+		return IPPortRange{}, &ParseError{Input: s, Kind: kind, Family: TypeIPPortRange, Cause: err}
+	}
+
+	if ipAddr.IPPort() != 0 {
+		return IPPortRange{}, &ParseError{Input: s, Kind: KindPort, Family: TypeIPPortRange, Cause: fmt.Errorf("address %+q can not have a port", addrStr)}
+	}
+
+	low, high, err := ParsePortRange(rangeStr)
+	if err != nil {
+		return IPPortRange{}, &ParseError{Input: s, Kind: KindPort, Family: TypeIPPortRange, Cause: err}
+	}
+
+	if opts.Strict {
+		lowStr, highStr, _ := strings.Cut(rangeStr, "-")
+		if hasLeadingZero(lowStr) || hasLeadingZero(highStr) {
+			return IPPortRange{}, &ParseError{Input: s, Kind: KindPort, Family: TypeIPPortRange, Cause: fmt.Errorf("zero-padded port in port range %+q", rangeStr)}
+		}
+	}
+
+	return IPPortRange{
+		Addr: ipAddr,
+		Low:  low,
+		High: high,
+	}, nil
+}
+
+// CmpAddress follows the Cmp() standard protocol and compares the address of
+// the receiver to the address of arg.  Returns 0 if the SockAddr arg is not an
+// IPPortRange.
+func (r IPPortRange) CmpAddress(sa SockAddr) int {
+	rb, ok := sa.(IPPortRange)
+	if !ok || r.Addr == nil {
+		return sortDeferDecision
+	}
+
+	return r.Addr.CmpAddress(rb.Addr)
+}
+
+// CmpPort follows the Cmp() standard protocol and returns:
+//
+//   - -1 If the receiver should sort first because its port range starts (or,
+//     if both start at the same port, ends) before arg
+//   - 0 if the SockAddr arg is not an IPPortRange, or its port range is equal
+//     to the receiving IPPortRange.
+//   - 1 If the argument should sort first.
+func (r IPPortRange) CmpPort(sa SockAddr) int {
+	rb, ok := sa.(IPPortRange)
+	if !ok {
+		return sortDeferDecision
+	}
+
+	switch {
+	case r.Low < rb.Low, r.Low == rb.Low && r.High < rb.High:
+		return sortReceiverBeforeArg
+	case r.Low == rb.Low && r.High == rb.High:
+		return sortDeferDecision
+	default:
+		return sortArgBeforeReceiver
+	}
+}
+
+// CmpRFC compares the address of the receiver, or the address of the
+// IPPortRange arg, against the RFC.  See IPAddr's CmpRFC().
+func (r IPPortRange) CmpRFC(rfcNum uint, sa SockAddr) int {
+	if rb, ok := sa.(IPPortRange); ok {
+		sa = rb.Addr
+	}
+
+	if r.Addr == nil || sa == nil {
+		return sortDeferDecision
+	}
+
+	return r.Addr.CmpRFC(rfcNum, sa)
+}
+
+// Contains returns true if sa is contained within the receiver.  An IPv4Addr
+// or IPv6Addr is contained if its address is within the receiver's network and
+// its port is within the receiver's port range.  An IPPortRange is contained if
+// both its network and its port range are within the receiver's.
+func (r IPPortRange) Contains(sa SockAddr) bool {
+	if r.Addr == nil {
+		return false
+	}
+
+	switch v := sa.(type) {
+	case IPPortRange:
+		return v.Addr != nil && r.Addr.Contains(v.Addr) && r.Low <= v.Low && v.High <= r.High
+	case IPv4Addr:
+		return r.Addr.Contains(v) && r.ContainsPort(v.Port)
+	case IPv6Addr:
+		return r.Addr.Contains(v) && r.ContainsPort(v.Port)
+	default:
+		return false
+	}
+}
+
+// ContainsPort returns true if port is within the receiver's port range.
+func (r IPPortRange) ContainsPort(port IPPort) bool {
+	return r.Low <= port && port <= r.High
+}
+
+// DialPacketArgs returns the arguments required to be passed to
+// net.DialUDP().  DialPacketArgs() will fail unless the IPPortRange is a
+// single host and a single port.
+func (r IPPortRange) DialPacketArgs() (network, dialArgs string) {
+	network, dialArgs = r.single().DialPacketArgs()
+	if r.Low != r.High {
+		return network, ""
+	}
+	return network, dialArgs
+}
+
+// DialStreamArgs returns the arguments required to be passed to
+// net.DialTCP().  DialStreamArgs() will fail unless the IPPortRange is a
+// single host and a single port.
+func (r IPPortRange) DialStreamArgs() (network, dialArgs string) {
+	network, dialArgs = r.single().DialStreamArgs()
+	if r.Low != r.High {
+		return network, ""
+	}
+	return network, dialArgs
+}
+
+// Equal returns true if a SockAddr is an IPPortRange with an equal address and
+// port range.
+func (r IPPortRange) Equal(sa SockAddr) bool {
+	rb, ok := sa.(IPPortRange)
+	if !ok {
+		return false
+	}
+
+	return r.Addr.Equal(rb.Addr) && r.Low == rb.Low && r.High == rb.High
+}
+
+// ListenPacketArgs returns the arguments required to be passed to
+// net.ListenUDP().  ListenPacketArgs() will fail unless the IPPortRange is a
+// single port.
+func (r IPPortRange) ListenPacketArgs() (network, listenArgs string) {
+	network, listenArgs = r.single().ListenPacketArgs()
+	if r.Low != r.High {
+		return network, ""
+	}
+	return network, listenArgs
+}
+
+// ListenStreamArgs returns the arguments required to be passed to
+// net.ListenTCP().  ListenStreamArgs() will fail unless the IPPortRange is a
+// single port.
+func (r IPPortRange) ListenStreamArgs() (network, listenArgs string) {
+	network, listenArgs = r.single().ListenStreamArgs()
+	if r.Low != r.High {
+		return network, ""
+	}
+	return network, listenArgs
+}
+
+// PortRangeString returns the port range of the IPPortRange (e.g. `8000-8100`,
+// or `80` for a range of one port).
+func (r IPPortRange) PortRangeString() string {
+	if r.Low == r.High {
+		return strconv.Itoa(int(r.Low))
+	}
+
+	return fmt.Sprintf("%d-%d", r.Low, r.High)
+}
+
+// String returns the address and port range of the IPPortRange.  IPv6
+// addresses are enclosed in brackets (e.g. `[2001:db8::/32]:8000-8100`).
+func (r IPPortRange) String() string {
+	if r.Addr == nil {
+		return ":" + r.PortRangeString()
+	}
+
+	if r.Addr.Type() == TypeIPv6 {
+		return fmt.Sprintf("[%s]:%s", r.Addr.String(), r.PortRangeString())
+	}
+
+	return fmt.Sprintf("%s:%s", r.Addr.String(), r.PortRangeString())
+}
+
+// Type is used as a type switch and returns TypeIPPortRange
+func (IPPortRange) Type() SockAddrType {
+	return TypeIPPortRange
+}
+
+// single returns the receiver's address with its port set to the low end of
+// the port range.
+func (r IPPortRange) single() IPAddr {
+	switch v := r.Addr.(type) {
+	case IPv4Addr:
+		v.Port = r.Low
+		return v
+	case IPv6Addr:
+		v.Port = r.Low
+		return v
+	default:
+		return r.Addr
+	}
+}
+
+// IPPortRangeAttrs returns a list of attributes supported by the IPPortRange
+// type.  The attributes of the IPPortRange's address, except `port`, are also
+// available.
+func IPPortRangeAttrs() []AttrName {
+	return portRangeAttrs
+}
+
+// IPPortRangeAttr returns a string representation of an attribute for the
+// given IPPortRange.
+func IPPortRangeAttr(r IPPortRange, attrName AttrName) string {
+	fn, found := portRangeAttrMap[attrName]
+	if !found {
+		return ""
+	}
+
+	return fn(r)
+}
+
+// portRangeAttrInit is called once at init()
+func portRangeAttrInit() {
+	// Sorted for human readability
+	portRangeAttrs = []AttrName{
+		"port_range",
+		"port_low",
+		"port_high",
+	}
+
+	portRangeAttrMap = map[AttrName]func(r IPPortRange) string{
+		"port_high": func(r IPPortRange) string {
+			return fmt.Sprintf("%d", r.High)
+		},
+		"port_low": func(r IPPortRange) string {
+			return fmt.Sprintf("%d", r.Low)
+		},
+		"port_range": func(r IPPortRange) string {
+			return r.PortRangeString()
+		},
+	}
+}
@@ -0,0 +1,248 @@
+package sockaddr_test
+
+import (
+	"errors"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestNewIPPortRange(t *testing.T) {
+	tests := []struct {
+		input string
+		addr  string
+		low   sockaddr.IPPort
+		high  sockaddr.IPPort
+		str   string
+		kind  sockaddr.ParseErrorKind
+		fail  bool
+	}{
+		{
+			input: "10.0.0.0/8:8000-8100",
+			addr:  "10.0.0.0/8",
+			low:   8000,
+			high:  8100,
+			str:   "10.0.0.0/8:8000-8100",
+		},
+		{
+			input: "10.0.0.1:80-90",
+			addr:  "10.0.0.1",
+			low:   80,
+			high:  90,
+			str:   "10.0.0.1:80-90",
+		},
+		{
+			input: "10.0.0.0/8:80",
+			addr:  "10.0.0.0/8",
+			low:   80,
+			high:  80,
+			str:   "10.0.0.0/8:80",
+		},
+		{
+			input: "[2001:db8::/32]:8000-8100",
+			addr:  "2001:db8::/32",
+			low:   8000,
+			high:  8100,
+			str:   "[2001:db8::/32]:8000-8100",
+		},
+		{
+			input: "[fe80::1%eth0]:0-65535",
+			addr:  "fe80::1%eth0",
+			low:   0,
+			high:  65535,
+			str:   "[fe80::1%eth0]:0-65535",
+		},
+		{input: "2001:db8::1:80-90", kind: sockaddr.KindSyntax, fail: true},
+		{input: "10.0.0.1:90-80", kind: sockaddr.KindPort, fail: true},
+		{input: "10.0.0.1:80-", kind: sockaddr.KindPort, fail: true},
+		{input: "[::1]:1-65536", kind: sockaddr.KindPort, fail: true},
+		{input: "10.0.0.0/33:80-90", kind: sockaddr.KindMask, fail: true},
+		{input: "10.0.0.1", kind: sockaddr.KindSyntax, fail: true},
+		{input: "[::1]", kind: sockaddr.KindSyntax, fail: true},
+		{input: "example.com:80-90", kind: sockaddr.KindSyntax, fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			r, err := sockaddr.NewIPPortRange(test.input)
+			if test.fail {
+				var pe *sockaddr.ParseError
+				if !errors.As(err, &pe) {
+					t.Fatalf("expected %q to fail with a ParseError, got %v (%v)", test.input, r, err)
+				}
+				if pe.Kind != test.kind {
+					t.Fatalf("wrong error kind: %s vs %s: %v", pe.Kind, test.kind, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse %q: %v", test.input, err)
+			}
+
+			if r.Addr.String() != test.addr {
+				t.Errorf("wrong address: %q vs %q", r.Addr, test.addr)
+			}
+			if r.Low != test.low || r.High != test.high {
+				t.Errorf("wrong port range: %d-%d vs %d-%d", r.Low, r.High, test.low, test.high)
+			}
+			if r.String() != test.str {
+				t.Errorf("wrong string: %q vs %q", r.String(), test.str)
+			}
+			if !r.Equal(sockaddr.MustIPPortRange(r.String())) {
+				t.Errorf("failed round-trip: %q", r)
+			}
+
+			sa, err := sockaddr.NewSockAddr(test.input)
+			if err != nil {
+				t.Fatalf("NewSockAddr unable to parse %q: %v", test.input, err)
+			}
+			if sa.Type() != sockaddr.TypeIPPortRange || !sa.Equal(r) {
+				t.Errorf("NewSockAddr mismatch: %v (%s)", sa, sa.Type())
+			}
+		})
+	}
+}
+
+func TestIPPortRange_Contains(t *testing.T) {
+	r := sockaddr.MustIPPortRange("10.0.0.0/8:8000-8100")
+
+	tests := []struct {
+		sa       sockaddr.SockAddr
+		contains bool
+	}{
+		{sa: sockaddr.MustIPv4Addr("10.1.2.3:8000"), contains: true},
+		{sa: sockaddr.MustIPv4Addr("10.1.2.3:8100"), contains: true},
+		{sa: sockaddr.MustIPPortRange("10.1.0.0/16:8050-8060"), contains: true},
+		{sa: sockaddr.MustIPPortRange("10.0.0.0/8:8000-8100"), contains: true},
+		{sa: sockaddr.MustIPv4Addr("10.1.2.3:7999"), contains: false},
+		{sa: sockaddr.MustIPv4Addr("10.1.2.3:8101"), contains: false},
+		{sa: sockaddr.MustIPv4Addr("10.1.2.3"), contains: false},
+		{sa: sockaddr.MustIPv4Addr("11.1.2.3:8050"), contains: false},
+		{sa: sockaddr.MustIPPortRange("10.1.0.0/16:8050-8200"), contains: false},
+		{sa: sockaddr.MustIPPortRange("0.0.0.0/0:8050-8060"), contains: false},
+		{sa: sockaddr.MustIPv6Addr("[2001:db8::1]:8050"), contains: false},
+		{sa: sockaddr.MustUnixSock("/tmp/foo.sock"), contains: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.sa.String(), func(t *testing.T) {
+			if got := r.Contains(test.sa); got != test.contains {
+				t.Errorf("expected Contains(%s) to be %t", test.sa, test.contains)
+			}
+		})
+	}
+
+	r6 := sockaddr.MustIPPortRange("[2001:db8::/32]:443")
+	if !r6.Contains(sockaddr.MustIPv6Addr("[2001:db8::1]:443")) {
+		t.Errorf("expected %s to contain [2001:db8::1]:443", r6)
+	}
+	if r6.Contains(sockaddr.MustIPv6Addr("[2001:db8::1]:80")) {
+		t.Errorf("expected %s to not contain [2001:db8::1]:80", r6)
+	}
+
+	// The zero value must not panic.
+	var zero sockaddr.IPPortRange
+	if zero.Contains(sockaddr.MustIPv4Addr("10.1.2.3:8000")) || r.Contains(zero) {
+		t.Errorf("expected the zero IPPortRange to contain and be contained by nothing")
+	}
+	if cmp := zero.CmpRFC(1918, r); cmp != 0 {
+		t.Errorf("expected CmpRFC of the zero IPPortRange to defer, received %d", cmp)
+	}
+	if cmp := r.CmpRFC(1918, zero); cmp != 0 {
+		t.Errorf("expected CmpRFC against the zero IPPortRange to defer, received %d", cmp)
+	}
+}
+
+func TestIPPortRange_Args(t *testing.T) {
+	tests := []struct {
+		input        string
+		dialStream   string
+		listenStream string
+	}{
+		{input: "10.0.0.1:80", dialStream: "10.0.0.1:80", listenStream: "10.0.0.1:80"},
+		{input: "10.0.0.1:80-90", dialStream: "", listenStream: ""},
+		{input: "[2001:db8::1]:443", dialStream: "[2001:db8::1]:443", listenStream: "[2001:db8::1]:443"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			r := sockaddr.MustIPPortRange(test.input)
+			if _, args := r.DialStreamArgs(); args != test.dialStream {
+				t.Errorf("wrong DialStreamArgs: %q vs %q", args, test.dialStream)
+			}
+			if _, args := r.ListenStreamArgs(); args != test.listenStream {
+				t.Errorf("wrong ListenStreamArgs: %q vs %q", args, test.listenStream)
+			}
+		})
+	}
+}
+
+func TestIPPortRangeAttrs(t *testing.T) {
+	const expectedNumAttrs = 3
+	attrs := sockaddr.IPPortRangeAttrs()
+	if len(attrs) != expectedNumAttrs {
+		t.Fatalf("wrong number of IPPortRangeAttrs: %d vs %d", len(attrs), expectedNumAttrs)
+	}
+
+	r := sockaddr.MustIPPortRange("10.0.0.0/8:8000-8100")
+	for attr, want := range map[sockaddr.AttrName]string{
+		"port_range": "8000-8100",
+		"port_low":   "8000",
+		"port_high":  "8100",
+		"address":    "10.0.0.0",
+		"mask_bits":  "8",
+		"type":       "port_range",
+		"string":     "10.0.0.0/8:8000-8100",
+	} {
+		got, err := sockaddr.Attr(r, attr)
+		if err != nil {
+			t.Fatalf("unable to get attr %q: %v", attr, err)
+		}
+		if got != want {
+			t.Errorf("wrong %q: %q vs %q", attr, got, want)
+		}
+	}
+
+	if _, err := sockaddr.Attr(r, "port"); err == nil {
+		t.Errorf("expected the port attribute to be unsupported")
+	}
+}
+
+func TestIfByPortRange(t *testing.T) {
+	ifAddrs := sockaddr.IfAddrs{
+		sockaddr.IfAddr{SockAddr: sockaddr.MustIPv4Addr("10.0.0.1:8000")},
+		sockaddr.IfAddr{SockAddr: sockaddr.MustIPv6Addr("[2001:db8::1]:8100")},
+		sockaddr.IfAddr{SockAddr: sockaddr.MustIPv4Addr("10.0.0.1:8101")},
+		sockaddr.IfAddr{SockAddr: sockaddr.MustIPv4Addr("10.0.0.1")},
+		sockaddr.IfAddr{SockAddr: sockaddr.MustIPPortRange("10.0.0.0/8:8050-8060")},
+		sockaddr.IfAddr{SockAddr: sockaddr.MustIPPortRange("10.0.0.0/8:8050-9000")},
+		sockaddr.IfAddr{SockAddr: sockaddr.MustUnixSock("/tmp/foo.sock")},
+	}
+
+	matched, excluded, err := sockaddr.IfByPortRange("8000-8100", ifAddrs)
+	if err != nil {
+		t.Fatalf("unable to filter by port range: %v", err)
+	}
+
+	want := []string{"10.0.0.1:8000", "[2001:db8::1]:8100", "10.0.0.0/8:8050-8060"}
+	if len(matched) != len(want) {
+		t.Fatalf("wrong number of matches: %v", matched)
+	}
+	for i, ifAddr := range matched {
+		if ifAddr.SockAddr.String() != want[i] {
+			t.Errorf("[%d] wrong match: %q vs %q", i, ifAddr.SockAddr, want[i])
+		}
+	}
+	if len(excluded) != len(ifAddrs)-len(want) {
+		t.Errorf("wrong number of exclusions: %v", excluded)
+	}
+
+	included, err := sockaddr.IncludeIfs("port_range", "8101", ifAddrs)
+	if err != nil || len(included) != 1 || included[0].SockAddr.String() != "10.0.0.1:8101" {
+		t.Errorf("wrong include result: %v (%v)", included, err)
+	}
+
+	if _, _, err := sockaddr.IfByPortRange("9000-8000", ifAddrs); err == nil || errors.Unwrap(err) == nil {
+		t.Errorf("expected an inverted port range to fail with a wrapped cause, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package sockaddr
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// Resolver looks up the IP addresses of a host.  *net.Resolver satisfies the
+// Resolver interface, and so does StaticResolver.  Tests may also point a
+// *net.Resolver at a local stub DNS server using its Dial field.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// StaticResolver is a Resolver that answers lookups from a fixed map of
+// hostnames to IP address strings (e.g. `"db.internal": {"10.0.0.5"}`).
+// Hostnames are matched case-insensitively and IPv6 addresses may include a
+// zone (e.g. `fe80::1%eth0`).
+type StaticResolver map[string][]string
+
+// LookupIPAddr returns the addresses of host from the StaticResolver.
+func (sr StaticResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	host = strings.TrimSuffix(host, ".")
+
+	addrs, found := sr[host]
+	if !found {
+		for name, nameAddrs := range sr {
+			if strings.EqualFold(strings.TrimSuffix(name, "."), host) {
+				addrs, found = nameAddrs, true
+				break
+			}
+		}
+	}
+
+	if !found {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	ipAddrs := make([]net.IPAddr, 0, len(addrs))
+	for _, addr := range addrs {
+		ipStr, zone := addr, ""
+		if i := strings.IndexByte(addr, '%'); i != -1 {
+			ipStr, zone = addr[:i], addr[i+1:]
+		}
+
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return nil, &net.DNSError{Err: "invalid IP address " + addr, Name: host}
+		}
+
+		ipAddrs = append(ipAddrs, net.IPAddr{IP: ip, Zone: zone})
+	}
+
+	return ipAddrs, nil
+}
@@ -0,0 +1,245 @@
+package sockaddr
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ipv4ReverseDNSSuffix is the domain of IPv4 PTR names (RFC 1035).
+	ipv4ReverseDNSSuffix = "in-addr.arpa"
+
+	// ipv6ReverseDNSSuffix is the domain of IPv6 PTR names (RFC 3596).
+	ipv6ReverseDNSSuffix = "ip6.arpa"
+)
+
+// ReverseDNS returns the PTR name of the IPv4Addr's address (e.g.
+// `1.2.0.10.in-addr.arpa` for `10.0.2.1`).  The name is not terminated by a
+// dot.
+func (ipv4 IPv4Addr) ReverseDNS() string {
+	return ipv4ReverseName(uint32(ipv4.Address), IPv4len)
+}
+
+// ReverseZones returns the names of the reverse DNS zones that exactly cover
+// the IPv4Addr's network, in ascending order.  Networks that are not
+// octet-aligned are covered by the zones of their subnets at the next octet
+// boundary (e.g. `10.0.0.0/22` returns `0.0.10.in-addr.arpa` through
+// `3.0.10.in-addr.arpa`).  Networks smaller than a /24 use the RFC 2317
+// classless delegation name (e.g. `64/26.2.0.192.in-addr.arpa` for
+// `192.0.2.64/26`), and a /32 returns the PTR name of its address.
+func (ipv4 IPv4Addr) ReverseZones() ([]string, error) {
+	ones, err := ipv4.prefixLen()
+	if err != nil {
+		return nil, err
+	}
+
+	network := uint32(ipv4.NetworkAddress())
+	switch {
+	case ones == IPv4len*8:
+		return []string{ipv4.ReverseDNS()}, nil
+	case ones > 24:
+		return []string{fmt.Sprintf("%d/%d.%s", network&0xff, ones, ipv4ReverseName(network, 3))}, nil
+	}
+
+	zoneLen := (ones + 7) / 8 * 8
+	subnets, err := ipv4.Subnets(zoneLen)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]string, 0, 1<<uint(zoneLen-ones))
+	for subnet := range subnets {
+		zones = append(zones, ipv4ReverseName(uint32(subnet.(IPv4Addr).Address), zoneLen/8))
+	}
+
+	return zones, nil
+}
+
+// ReverseDNS returns the PTR name of the IPv6Addr's address (e.g.
+// `1.0.0.0.[...].8.b.d.0.1.0.0.2.ip6.arpa` for `2001:db8::1`).  The name is
+// not terminated by a dot.
+func (ipv6 IPv6Addr) ReverseDNS() string {
+	return ipv6ReverseName(Uint128(ipv6.Address), IPv6len*2)
+}
+
+// ReverseZones returns the names of the reverse DNS zones that exactly cover
+// the IPv6Addr's network, in ascending order.  Networks that are not
+// nibble-aligned are covered by the zones of their subnets at the next nibble
+// boundary (e.g. `2001:db8::/30` returns the zones of `2001:db8::/32`,
+// `2001:db9::/32`, `2001:dba::/32` and `2001:dbb::/32`).
+func (ipv6 IPv6Addr) ReverseZones() ([]string, error) {
+	ones, err := ipv6.prefixLen()
+	if err != nil {
+		return nil, err
+	}
+
+	zoneLen := (ones + 3) / 4 * 4
+	subnets, err := ipv6.Subnets(zoneLen)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]string, 0, 1<<uint(zoneLen-ones))
+	for subnet := range subnets {
+		zones = append(zones, ipv6ReverseName(Uint128(subnet.(IPv6Addr).Address), zoneLen/4))
+	}
+
+	return zones, nil
+}
+
+// ParseReverseDNS returns the address or network named by a PTR name or
+// reverse DNS zone, the inverse of ReverseDNS() and ReverseZones().  Complete
+// names return a host address (e.g. `1.2.0.10.in-addr.arpa` returns
+// `10.0.2.1`) and partial names return the network of the zone (e.g.
+// `2.0.10.in-addr.arpa` returns `10.0.2.0/24`).  RFC 2317 classless names may
+// use either the `64/26` or `64-127` form of the first label.  The name is
+// case-insensitive and may be terminated by a dot.
+func ParseReverseDNS(name string) (IPAddr, error) {
+	s := strings.ToLower(strings.TrimSuffix(name, "."))
+	switch {
+	case s == ipv4ReverseDNSSuffix || strings.HasSuffix(s, "."+ipv4ReverseDNSSuffix):
+		return parseIPv4ReverseDNS(name, strings.TrimSuffix(s, ipv4ReverseDNSSuffix))
+	case s == ipv6ReverseDNSSuffix || strings.HasSuffix(s, "."+ipv6ReverseDNSSuffix):
+		return parseIPv6ReverseDNS(name, strings.TrimSuffix(s, ipv6ReverseDNSSuffix))
+	default:
+		return nil, &ParseError{Input: name, Kind: KindSyntax, Family: TypeIP, Cause: fmt.Errorf("not in %s or %s", ipv4ReverseDNSSuffix, ipv6ReverseDNSSuffix)}
+	}
+}
+
+// parseIPv4ReverseDNS returns the IPv4Addr for the labels of a name in
+// in-addr.arpa.  labels is empty or ends with a dot.
+func parseIPv4ReverseDNS(name, labels string) (IPAddr, error) {
+	var octets []string
+	if labels != "" {
+		octets = strings.Split(strings.TrimSuffix(labels, "."), ".")
+	}
+
+	syntaxErr := func(cause error) error {
+		return &ParseError{Input: name, Kind: KindSyntax, Family: TypeIPv4, Cause: cause}
+	}
+
+	// An RFC 2317 classless delegation (e.g. `64/26.2.0.192`)
+	var classless string
+	if len(octets) == 4 && strings.ContainsAny(octets[0], "/-") {
+		classless, octets = octets[0], octets[1:]
+	}
+
+	if len(octets) > IPv4len {
+		return nil, syntaxErr(errors.New("too many labels"))
+	}
+
+	var address uint32
+	for i, octet := range octets {
+		v, err := strconv.ParseUint(octet, 10, 8)
+		if err != nil {
+			return nil, syntaxErr(fmt.Errorf("invalid octet %+q", octet))
+		}
+		address |= uint32(v) << uint(8*(IPv4len-len(octets)+i))
+	}
+
+	prefixLen := len(octets) * 8
+	if classless != "" {
+		low, ones, err := parseRFC2317Label(classless)
+		if err != nil {
+			return nil, syntaxErr(err)
+		}
+		address |= low
+		prefixLen = ones
+	}
+
+	return IPv4Addr{
+		Address: IPv4Address(address),
+		Mask:    IPv4Mask(ipv4HostMaskLen(prefixLen)),
+	}, nil
+}
+
+// parseRFC2317Label returns the first address and prefix length of the
+// classless delegation label of an RFC 2317 name (e.g. `64/26` or `64-127`).
+func parseRFC2317Label(label string) (low uint32, prefixLen int, err error) {
+	if lowStr, lenStr, found := strings.Cut(label, "/"); found {
+		l, err := strconv.ParseUint(lowStr, 10, 8)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid classless label %+q", label)
+		}
+
+		n, err := strconv.ParseUint(lenStr, 10, 8)
+		if err != nil || n <= 24 || n > 32 {
+			return 0, 0, fmt.Errorf("%w: invalid prefix length in classless label %+q", ErrInvalidMask, label)
+		}
+
+		if uint32(l)&^ipv4HostMaskLen(int(n)) != 0 {
+			return 0, 0, fmt.Errorf("classless label %+q is not aligned to its prefix length", label)
+		}
+
+		return uint32(l), int(n), nil
+	}
+
+	lowStr, highStr, _ := strings.Cut(label, "-")
+	l, lErr := strconv.ParseUint(lowStr, 10, 8)
+	h, hErr := strconv.ParseUint(highStr, 10, 8)
+	if lErr != nil || hErr != nil || l > h {
+		return 0, 0, fmt.Errorf("invalid classless label %+q", label)
+	}
+
+	size := h - l + 1
+	if size&(size-1) != 0 || l%size != 0 {
+		return 0, 0, fmt.Errorf("classless label %+q is not a CIDR block", label)
+	}
+
+	return uint32(l), IPv4len*8 - (bits.Len64(size) - 1), nil
+}
+
+// parseIPv6ReverseDNS returns the IPv6Addr for the labels of a name in
+// ip6.arpa.  labels is empty or ends with a dot.
+func parseIPv6ReverseDNS(name, labels string) (IPAddr, error) {
+	var nibbles []string
+	if labels != "" {
+		nibbles = strings.Split(strings.TrimSuffix(labels, "."), ".")
+	}
+
+	if len(nibbles) > IPv6len*2 {
+		return nil, &ParseError{Input: name, Kind: KindSyntax, Family: TypeIPv6, Cause: errors.New("too many labels")}
+	}
+
+	var address Uint128
+	for i, nibble := range nibbles {
+		v, err := strconv.ParseUint(nibble, 16, 4)
+		if err != nil || len(nibble) != 1 {
+			return nil, &ParseError{Input: name, Kind: KindSyntax, Family: TypeIPv6, Cause: fmt.Errorf("invalid nibble %+q", nibble)}
+		}
+		address = address.Or(Uint128{Lo: v}.Lsh(uint(4 * (IPv6len*2 - len(nibbles) + i))))
+	}
+
+	return IPv6Addr{
+		Address: IPv6Address(address),
+		Mask:    IPv6Mask(uint128Mask(len(nibbles) * 4)),
+	}, nil
+}
+
+// ipv4ReverseName returns the reverse DNS name of the first n octets of
+// address.
+func ipv4ReverseName(address uint32, n int) string {
+	labels := make([]string, 0, n+1)
+	for i := n - 1; i >= 0; i-- {
+		labels = append(labels, strconv.Itoa(int(address>>uint(8*(IPv4len-1-i))&0xff)))
+	}
+
+	return strings.Join(append(labels, ipv4ReverseDNSSuffix), ".")
+}
+
+// ipv6ReverseName returns the reverse DNS name of the first n nibbles of
+// address.
+func ipv6ReverseName(address Uint128, n int) string {
+	var b strings.Builder
+	for i := n - 1; i >= 0; i-- {
+		nibble := address.Rsh(uint(4*(IPv6len*2-1-i))).Lo & 0xf
+		b.WriteString(strconv.FormatUint(nibble, 16))
+		b.WriteByte('.')
+	}
+
+	b.WriteString(ipv6ReverseDNSSuffix)
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package sockaddr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestIPAddr_ReverseDNS(t *testing.T) {
+	tests := []struct {
+		input string
+		name  string
+	}{
+		{"10.0.2.1", "1.2.0.10.in-addr.arpa"},
+		{"192.0.2.77/24", "77.2.0.192.in-addr.arpa"},
+		{"0.0.0.0", "0.0.0.0.in-addr.arpa"},
+		{"2001:db8::1", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+		{"fe80::abcd%eth0", "d.c.b.a.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.e.f.ip6.arpa"},
+	}
+
+	for _, test := range tests {
+		sa := sockaddr.MustIPAddr(test.input)
+		name, err := sockaddr.Attr(sa, "reverse_dns")
+		if err != nil || name != test.name {
+			t.Errorf("reverse_dns of %s: expected %q, received %q, %v", test.input, test.name, name, err)
+		}
+
+		ipAddr, err := sockaddr.ParseReverseDNS(name + ".")
+		if err != nil {
+			t.Errorf("ParseReverseDNS(%q): %v", name, err)
+			continue
+		}
+		// PTR names do not include the zone.
+		if ipAddr.NetIP().String() != sa.NetIP().String() || ipAddr.Maskbits() != len(*sa.NetIP())*8 {
+			t.Errorf("ParseReverseDNS(%q): expected %s, received %s", name, sa.NetIP(), ipAddr)
+		}
+	}
+}
+
+func TestIPAddr_ReverseZones(t *testing.T) {
+	tests := []struct {
+		input string
+		zones []string
+		fail  bool
+	}{
+		{input: "10.0.0.0/8", zones: []string{"10.in-addr.arpa"}},
+		{input: "0.0.0.0/0", zones: []string{"in-addr.arpa"}},
+		{input: "10.0.0.0/22", zones: []string{"0.0.10.in-addr.arpa", "1.0.10.in-addr.arpa", "2.0.10.in-addr.arpa", "3.0.10.in-addr.arpa"}},
+		{input: "192.0.2.0/24", zones: []string{"2.0.192.in-addr.arpa"}},
+		{input: "192.0.2.64/26", zones: []string{"64/26.2.0.192.in-addr.arpa"}},
+		{input: "192.0.2.10/32", zones: []string{"10.2.0.192.in-addr.arpa"}},
+		{input: "2001:db8::/32", zones: []string{"8.b.d.0.1.0.0.2.ip6.arpa"}},
+		{input: "2001:db8::/30", zones: []string{"8.b.d.0.1.0.0.2.ip6.arpa", "9.b.d.0.1.0.0.2.ip6.arpa", "a.b.d.0.1.0.0.2.ip6.arpa", "b.b.d.0.1.0.0.2.ip6.arpa"}},
+		{input: "2001:db8:1:2::/63", zones: []string{"2.0.0.0.1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", "3.0.0.0.1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"}},
+		{input: "::/0", zones: []string{"ip6.arpa"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			var zones []string
+			var err error
+			switch v := sockaddr.MustIPAddr(test.input).(type) {
+			case sockaddr.IPv4Addr:
+				zones, err = v.ReverseZones()
+			case sockaddr.IPv6Addr:
+				zones, err = v.ReverseZones()
+			}
+			if err != nil {
+				t.Fatalf("ReverseZones: %v", err)
+			}
+			if !reflect.DeepEqual(zones, test.zones) {
+				t.Fatalf("ReverseZones: expected %q, received %q", test.zones, zones)
+			}
+
+			// Every zone parses back to a network within the input.
+			network := sockaddr.MustIPAddr(test.input)
+			for _, zone := range zones {
+				ipAddr, err := sockaddr.ParseReverseDNS(zone)
+				if err != nil {
+					t.Fatalf("ParseReverseDNS(%q): %v", zone, err)
+				}
+				if !network.Contains(ipAddr) && !ipAddr.Contains(network) {
+					t.Fatalf("ParseReverseDNS(%q): %s is unrelated to %s", zone, ipAddr, network)
+				}
+			}
+		})
+	}
+
+	noncanonical := sockaddr.MustIPv4Addr("10.0.0.0/8")
+	noncanonical.Mask = 0xff00ff00
+	if _, err := noncanonical.ReverseZones(); !errors.Is(err, sockaddr.ErrInvalidMask) {
+		t.Errorf("expected a non-contiguous mask to fail with ErrInvalidMask, received %v", err)
+	}
+}
+
+func TestParseReverseDNS(t *testing.T) {
+	tests := []struct {
+		input  string
+		output string
+		fail   bool
+	}{
+		{input: "1.2.0.10.in-addr.arpa", output: "10.0.2.1"},
+		{input: "1.2.0.10.IN-ADDR.ARPA.", output: "10.0.2.1"},
+		{input: "2.0.10.in-addr.arpa", output: "10.0.2.0/24"},
+		{input: "10.in-addr.arpa.", output: "10.0.0.0/8"},
+		{input: "in-addr.arpa", output: "0.0.0.0/0"},
+		{input: "64/26.2.0.192.in-addr.arpa", output: "192.0.2.64/26"},
+		{input: "64-127.2.0.192.in-addr.arpa", output: "192.0.2.64/26"},
+		{input: "8.b.d.0.1.0.0.2.ip6.arpa", output: "2001:db8::/32"},
+		{input: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.B.D.0.1.0.0.2.ip6.arpa.", output: "2001:db8::1"},
+		{input: "ip6.arpa", output: "::/0"},
+		{input: "example.com", fail: true},
+		{input: "xin-addr.arpa", fail: true},
+		{input: "256.2.0.10.in-addr.arpa", fail: true},
+		{input: "1.1.2.0.10.in-addr.arpa", fail: true},
+		{input: "..in-addr.arpa", fail: true},
+		{input: "65/26.2.0.192.in-addr.arpa", fail: true},
+		{input: "64/24.2.0.192.in-addr.arpa", fail: true},
+		{input: "64-100.2.0.192.in-addr.arpa", fail: true},
+		{input: "10.8.b.d.0.1.0.0.2.ip6.arpa", fail: true},
+		{input: "g.8.b.d.0.1.0.0.2.ip6.arpa", fail: true},
+	}
+
+	for _, test := range tests {
+		ipAddr, err := sockaddr.ParseReverseDNS(test.input)
+		if test.fail {
+			var pe *sockaddr.ParseError
+			if !errors.As(err, &pe) {
+				t.Errorf("expected %q to fail with a ParseError, received %v (%v)", test.input, ipAddr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseReverseDNS(%q): %v", test.input, err)
+			continue
+		}
+		if ipAddr.String() != test.output {
+			t.Errorf("ParseReverseDNS(%q): expected %q, received %q", test.input, test.output, ipAddr)
+		}
+	}
+}
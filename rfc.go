@@ -5,8 +5,13 @@ package sockaddr
 const ForwardingBlacklist = 4294967295
 const ForwardingBlacklistRFC = "4294967295"
 
-// IsRFC tests to see if an SockAddr matches the specified RFC
+// IsRFC tests to see if an SockAddr matches the specified RFC.  An IPRange
+// matches if all of its addresses are within one of the RFC's networks.
 func IsRFC(rfcNum uint, sa SockAddr) bool {
+	if r, ok := sa.(IPRange); ok {
+		return r.isRFC(rfcNum)
+	}
+
 	rfcNetMap := KnownRFCs()
 	rfcNets, ok := rfcNetMap[rfcNum]
 	if !ok {
@@ -23,6 +28,18 @@ func IsRFC(rfcNum uint, sa SockAddr) bool {
 	return contained
 }
 
+// IsRFCUnmapped tests to see if a SockAddr matches the specified RFC.  Unlike
+// IsRFC(), IPv4-mapped and IPv4-compatible IPv6 addresses are tested using
+// their embedded IPv4 address (e.g. `::ffff:10.0.0.1` matches RFC 1918).  See
+// IPv6Addr.Unmap().
+func IsRFCUnmapped(rfcNum uint, sa SockAddr) bool {
+	if ipv6, ok := sa.(IPv6Addr); ok {
+		sa = ipv6.Unmap()
+	}
+
+	return IsRFC(rfcNum, sa)
+}
+
 // KnownRFCs returns an initial set of known RFCs.
 //
 // NOTE (sean@): As this list evolves over time, please submit patches to keep
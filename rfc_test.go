@@ -42,6 +42,18 @@ func TestIsRFC(t *testing.T) {
 			rfcNum: 1918,
 			result: true,
 		},
+		{
+			name:   "rfc1918 range pass",
+			sa:     sockaddr.MustIPRange("10.0.0.0-10.0.0.255"),
+			rfcNum: 1918,
+			result: true,
+		},
+		{
+			name:   "rfc1918 range fail",
+			sa:     sockaddr.MustIPRange("9.255.255.250-10.0.0.5"),
+			rfcNum: 1918,
+			result: false,
+		},
 		{
 			name:   "invalid rfc",
 			sa:     sockaddr.MustIPv4Addr("192.168.0.0/16"),
@@ -61,3 +73,64 @@ func TestIsRFC(t *testing.T) {
 		}
 	}
 }
+
+func TestIsRFCUnmapped(t *testing.T) {
+	tests := []struct {
+		name   string
+		sa     sockaddr.SockAddr
+		rfcNum uint
+		result bool
+	}{
+		{
+			name:   "mapped rfc1918 pass",
+			sa:     sockaddr.MustIPv6Addr("::ffff:192.168.1.1"),
+			rfcNum: 1918,
+			result: true,
+		},
+		{
+			name:   "mapped rfc1918 fail",
+			sa:     sockaddr.MustIPv6Addr("::ffff:1.2.3.4"),
+			rfcNum: 1918,
+			result: false,
+		},
+		{
+			name:   "compat rfc1918 pass",
+			sa:     sockaddr.MustIPv6Addr("::10.1.2.3"),
+			rfcNum: 1918,
+			result: true,
+		},
+		{
+			name:   "mapped rfc4291 fail",
+			sa:     sockaddr.MustIPv6Addr("::ffff:192.168.1.1"),
+			rfcNum: 4291,
+			result: false,
+		},
+		{
+			name:   "ipv4 rfc1918 pass",
+			sa:     sockaddr.MustIPv4Addr("10.0.0.1"),
+			rfcNum: 1918,
+			result: true,
+		},
+		{
+			name:   "ipv6 rfc4291 pass",
+			sa:     sockaddr.MustIPv6Addr("fe80::1"),
+			rfcNum: 4291,
+			result: true,
+		},
+	}
+
+	for i, test := range tests {
+		if test.name == "" {
+			t.Fatalf("test %d needs a name", i)
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			if result := sockaddr.IsRFCUnmapped(test.rfcNum, test.sa); result != test.result {
+				t.Fatalf("expected %t, got %t", test.result, result)
+			}
+			if test.sa.Type() == sockaddr.TypeIPv6 && sockaddr.IsRFC(1918, test.sa) {
+				t.Fatalf("IsRFC must not unmap %s", test.sa)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package sockaddr
 
 import (
-	"errors"
 	"os/exec"
 )
 
@@ -28,7 +27,7 @@ func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 
 	var ifName string
 	if ifName, err = parseDefaultIfNameFromIPCmdAndroid(string(out)); err != nil {
-		return "", errors.New("No default interface found")
+		return "", ErrNoDefaultRoute
 	}
 	return ifName, nil
 }
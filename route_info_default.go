@@ -2,9 +2,9 @@
 
 package sockaddr
 
-import "errors"
+import "fmt"
 
 // getDefaultIfName is the default interface function for unsupported platforms.
 func getDefaultIfName() (string, error) {
-	return "", errors.New("No default interface found (unsupported platform)")
+	return "", fmt.Errorf("%w (unsupported platform)", ErrNoDefaultRoute)
 }
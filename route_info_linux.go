@@ -3,7 +3,6 @@
 package sockaddr
 
 import (
-	"errors"
 	"os/exec"
 )
 
@@ -36,7 +35,7 @@ func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 
 	var ifName string
 	if ifName, err = parseDefaultIfNameFromIPCmd(string(out)); err != nil {
-		return "", errors.New("No default interface found")
+		return "", ErrNoDefaultRoute
 	}
 	return ifName, nil
 }
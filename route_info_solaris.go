@@ -1,7 +1,6 @@
 package sockaddr
 
 import (
-	"errors"
 	"os/exec"
 )
 
@@ -31,7 +30,7 @@ func (ri routeInfo) GetDefaultInterfaceName() (string, error) {
 
 	var ifName string
 	if ifName, err = parseDefaultIfNameFromRoute(string(out)); err != nil {
-		return "", errors.New("No default interface found")
+		return "", ErrNoDefaultRoute
 	}
 	return ifName, nil
 }
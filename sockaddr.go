@@ -2,8 +2,6 @@ package sockaddr
 
 import (
 	"encoding/json"
-	"fmt"
-	"strings"
 )
 
 type SockAddrType int
@@ -17,6 +15,15 @@ const (
 
 	// TypeIP is the union of TypeIPv4 and TypeIPv6
 	TypeIP = 0x6
+
+	// TypeHostname is an unresolved DNS hostname and port
+	TypeHostname = 0x8
+
+	// TypeIPPortRange is an IP address or network and a range of ports
+	TypeIPPortRange = 0x10
+
+	// TypeIPRange is an inclusive range of IPv4 or IPv6 addresses
+	TypeIPRange = 0x20
 )
 
 type SockAddr interface {
@@ -53,39 +60,24 @@ func init() {
 }
 
 // New creates a new SockAddr from the string.  The order in which New()
-// attempts to construct a SockAddr is: IPv4Addr, IPv6Addr, SockAddrUnix.
+// attempts to construct a SockAddr is: IPv4Addr, IPv6Addr, IPPortRange,
+// IPRange, SockAddrUnix.
 //
 // NOTE: New() relies on the heuristic wherein if the path begins with either a
 // '.'  or '/' character before creating a new UnixSock.  For UNIX sockets that
 // are absolute paths or are nested within a sub-directory, this works as
 // expected, however if the UNIX socket is contained in the current working
 // directory, this will fail unless the path begins with "./"
-// (e.g. "./my-local-socket").  Calls directly to NewUnixSock() do not suffer
-// this limitation.  Invalid IP addresses such as "256.0.0.0/-1" will run afoul
-// of this heuristic and be assumed to be a valid UNIX socket path (which they
-// are, but it is probably not what you want and you won't realize it until you
-// stat(2) the file system to discover it doesn't exist).
+// (e.g. "./my-local-socket").  Sockets in the Linux abstract namespace must
+// begin with '@' (e.g. "@my-abstract-socket").  Calls directly to
+// NewUnixSock() do not suffer this limitation.  Invalid IP addresses such as
+// "256.0.0.0/-1" will run afoul of this heuristic and be assumed to be a valid
+// UNIX socket path (which they are, but it is probably not what you want and
+// you won't realize it until you stat(2) the file system to discover it
+// doesn't exist).  Use ParseSockAddr() with ParseOptions.Strict to disable
+// these heuristics.
 func NewSockAddr(s string) (SockAddr, error) {
-	ipv4Addr, err := NewIPv4Addr(s)
-	if err == nil {
-		return ipv4Addr, nil
-	}
-
-	ipv6Addr, err := NewIPv6Addr(s)
-	if err == nil {
-		return ipv6Addr, nil
-	}
-
-	// Check to make sure the string begins with either a '.' or '/', or
-	// contains a '/'.
-	if len(s) > 1 && (strings.IndexAny(s[0:1], "./") != -1 || strings.IndexByte(s, '/') != -1) {
-		unixSock, err := NewUnixSock(s)
-		if err == nil {
-			return unixSock, nil
-		}
-	}
-
-	return nil, fmt.Errorf("Unable to convert %q to an IPv4 or IPv6 address, or a UNIX Socket", s)
+	return ParseSockAddr(s, ParseOptions{})
 }
 
 // ToIPAddr returns an IPAddr type or nil if the type conversion fails.
@@ -117,6 +109,37 @@ func ToIPv6Addr(sa SockAddr) *IPv6Addr {
 	}
 }
 
+// ToHostname returns a Hostname type or nil if the type conversion fails.
+func ToHostname(sa SockAddr) *Hostname {
+	switch v := sa.(type) {
+	case Hostname:
+		return &v
+	default:
+		return nil
+	}
+}
+
+// ToIPPortRange returns an IPPortRange type or nil if the type conversion
+// fails.
+func ToIPPortRange(sa SockAddr) *IPPortRange {
+	switch v := sa.(type) {
+	case IPPortRange:
+		return &v
+	default:
+		return nil
+	}
+}
+
+// ToIPRange returns an IPRange type or nil if the type conversion fails.
+func ToIPRange(sa SockAddr) *IPRange {
+	switch v := sa.(type) {
+	case IPRange:
+		return &v
+	default:
+		return nil
+	}
+}
+
 // ToUnixSock returns a UnixSock type or nil if the type conversion fails.
 func ToUnixSock(sa SockAddr) *UnixSock {
 	switch v := sa.(type) {
@@ -139,7 +162,8 @@ func SockAddrAttr(sa SockAddr, selector AttrName) string {
 }
 
 // String() for SockAddrType returns a string representation of the
-// SockAddrType (e.g. "IPv4", "IPv6", "UNIX", "IP", or "unknown").
+// SockAddrType (e.g. "IPv4", "IPv6", "UNIX", "hostname", "port_range",
+// "ip_range", "IP", or "unknown").
 func (sat SockAddrType) String() string {
 	switch sat {
 	case TypeIPv4:
@@ -151,6 +175,12 @@ func (sat SockAddrType) String() string {
 	// 	return "IP"
 	case TypeUnix:
 		return "UNIX"
+	case TypeHostname:
+		return "hostname"
+	case TypeIPPortRange:
+		return "port_range"
+	case TypeIPRange:
+		return "ip_range"
 	default:
 		panic("unsupported type")
 	}
@@ -102,6 +102,12 @@ func AscAddress(p1Ptr, p2Ptr *SockAddr) int {
 		return v.CmpAddress(p2)
 	case UnixSock:
 		return v.CmpAddress(p2)
+	case Hostname:
+		return v.CmpAddress(p2)
+	case IPPortRange:
+		return v.CmpAddress(p2)
+	case IPRange:
+		return v.CmpAddress(p2)
 	default:
 		return sortDeferDecision
 	}
@@ -118,6 +124,10 @@ func AscPort(p1Ptr, p2Ptr *SockAddr) int {
 		return v.CmpPort(p2)
 	case IPv6Addr:
 		return v.CmpPort(p2)
+	case Hostname:
+		return v.CmpPort(p2)
+	case IPPortRange:
+		return v.CmpPort(p2)
 	default:
 		return sortDeferDecision
 	}
@@ -152,8 +162,21 @@ func AscNetworkSize(p1Ptr, p2Ptr *SockAddr) int {
 		return sortDeferDecision
 	}
 
-	ipA := p1.(IPAddr)
-	ipB := p2.(IPAddr)
+	// IPRanges are not networks, compare the number of addresses instead.
+	// Like networks, larger ranges sort first.
+	if rA, ok := p1.(IPRange); ok {
+		rB, ok := p2.(IPRange)
+		if !ok {
+			return sortDeferDecision
+		}
+		return rB.Size().Cmp(rA.Size())
+	}
+
+	ipA, okA := p1.(IPAddr)
+	ipB, okB := p2.(IPAddr)
+	if !okA || !okB {
+		return sortDeferDecision
+	}
 
 	return bytes.Compare([]byte(*ipA.NetIPMask()), []byte(*ipB.NetIPMask()))
 }
@@ -0,0 +1,31 @@
+// +build !aix,!darwin,!dragonfly,!freebsd,!illumos,!linux,!netbsd,!openbsd,!solaris,!windows
+
+package sockaddr
+
+import (
+	"fmt"
+	"os"
+)
+
+// narrowUmask is a no-op because this platform has no umask.
+func narrowUmask(mask os.FileMode) (restore func()) {
+	return func() {}
+}
+
+// setReuseAddr returns an error because socket options are not supported on
+// this platform (e.g. Plan 9 or WebAssembly).
+func setReuseAddr(fd uintptr) error {
+	return fmt.Errorf("SO_REUSEADDR is not supported on this platform")
+}
+
+// setReusePort returns an error because socket options are not supported on
+// this platform.
+func setReusePort(fd uintptr) error {
+	return fmt.Errorf("SO_REUSEPORT is not supported on this platform")
+}
+
+// setV6Only returns an error because socket options are not supported on
+// this platform.
+func setV6Only(fd uintptr, v6Only bool) error {
+	return fmt.Errorf("IPV6_V6ONLY is not supported on this platform")
+}
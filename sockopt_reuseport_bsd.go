@@ -0,0 +1,8 @@
+// +build aix darwin dragonfly freebsd netbsd openbsd
+
+package sockaddr
+
+import "syscall"
+
+// soReusePort is the value of SO_REUSEPORT.
+const soReusePort = syscall.SO_REUSEPORT
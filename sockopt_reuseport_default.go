@@ -0,0 +1,6 @@
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!plan9,!windows
+
+package sockaddr
+
+// soReusePort is zero because SO_REUSEPORT is not supported on this platform.
+const soReusePort = 0
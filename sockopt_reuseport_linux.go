@@ -0,0 +1,7 @@
+// +build !mips,!mipsle,!mips64,!mips64le
+
+package sockaddr
+
+// soReusePort is the value of SO_REUSEPORT, which the syscall package does not
+// define on every architecture.
+const soReusePort = 0xf
@@ -0,0 +1,7 @@
+// +build linux,mips linux,mipsle linux,mips64 linux,mips64le
+
+package sockaddr
+
+// soReusePort is the value of SO_REUSEPORT on MIPS, which the syscall package
+// does not define on every architecture.
+const soReusePort = 0x200
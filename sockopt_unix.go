@@ -0,0 +1,51 @@
+// +build aix darwin dragonfly freebsd illumos linux netbsd openbsd solaris
+
+package sockaddr
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// umaskMu serializes the umask changes of narrowUmask().
+var umaskMu sync.Mutex
+
+// narrowUmask adds mask to the process umask until restore is called, so
+// that files created in the meantime are never more permissive than
+// requested.  The umask is process-wide, so calls are serialized.
+func narrowUmask(mask os.FileMode) (restore func()) {
+	umaskMu.Lock()
+	old := syscall.Umask(0)
+	syscall.Umask(old | int(mask.Perm()))
+
+	return func() {
+		syscall.Umask(old)
+		umaskMu.Unlock()
+	}
+}
+
+// setReuseAddr sets SO_REUSEADDR on the socket fd.
+func setReuseAddr(fd uintptr) error {
+	return syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1)
+}
+
+// setReusePort sets SO_REUSEPORT on the socket fd.
+func setReusePort(fd uintptr) error {
+	if soReusePort == 0 {
+		return fmt.Errorf("SO_REUSEPORT is not supported on this platform")
+	}
+
+	return syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, soReusePort, 1)
+}
+
+// setV6Only sets IPV6_V6ONLY on the IPv6 socket fd.
+func setV6Only(fd uintptr, v6Only bool) error {
+	v := 0
+	if v6Only {
+		v = 1
+	}
+
+	return syscall.SetsockoptInt(int(fd), syscall.IPPROTO_IPV6, syscall.IPV6_V6ONLY, v)
+}
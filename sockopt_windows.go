@@ -0,0 +1,35 @@
+package sockaddr
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// narrowUmask is a no-op because Windows has no umask.
+func narrowUmask(mask os.FileMode) (restore func()) {
+	return func() {}
+}
+
+// setReuseAddr is a no-op on Windows.  There, SO_REUSEADDR allows another
+// socket to bind to the same address and port and steal its traffic, while
+// rebinding an address in TIME_WAIT is already allowed without it.
+func setReuseAddr(fd uintptr) error {
+	return nil
+}
+
+// setReusePort returns an error because SO_REUSEPORT is not supported on
+// Windows.
+func setReusePort(fd uintptr) error {
+	return fmt.Errorf("SO_REUSEPORT is not supported on this platform")
+}
+
+// setV6Only sets IPV6_V6ONLY on the IPv6 socket fd.
+func setV6Only(fd uintptr, v6Only bool) error {
+	v := 0
+	if v6Only {
+		v = 1
+	}
+
+	return syscall.SetsockoptInt(syscall.Handle(fd), syscall.IPPROTO_IPV6, syscall.IPV6_V6ONLY, v)
+}
@@ -0,0 +1,276 @@
+package sockaddr
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Subnets returns an iterator over the subnets of the IPv4Addr's network with
+// a prefix length of newPrefixLen, in ascending order.  Subnets are generated
+// as the iterator advances.  For example, Subnets(26) on "10.0.0.0/24" yields
+// "10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26" and "10.0.0.192/26".
+func (ipv4 IPv4Addr) Subnets(newPrefixLen int) (iter.Seq[IPAddr], error) {
+	prefixLen, err := ipv4.checkSplit(newPrefixLen)
+	if err != nil {
+		return nil, err
+	}
+
+	network := uint32(ipv4.NetworkAddress())
+	mask := IPv4Mask(ipv4HostMaskLen(newPrefixLen))
+	count := uint64(1) << uint(newPrefixLen-prefixLen)
+	size := uint64(1) << uint(IPv4len*8-newPrefixLen)
+
+	return func(yield func(IPAddr) bool) {
+		for i := uint64(0); i < count; i++ {
+			if !yield(IPv4Addr{Address: IPv4Address(network + uint32(i*size)), Mask: mask}) {
+				return
+			}
+		}
+	}, nil
+}
+
+// Subnet returns the index'th subnet, starting at zero, of the IPv4Addr's
+// network with a prefix length of newPrefixLen (e.g. Subnet(26, 2) on
+// "10.0.0.0/24" returns "10.0.0.128/26").
+func (ipv4 IPv4Addr) Subnet(newPrefixLen int, index uint64) (IPAddr, error) {
+	prefixLen, err := ipv4.checkSplit(newPrefixLen)
+	if err != nil {
+		return nil, err
+	}
+
+	count := uint64(1) << uint(newPrefixLen-prefixLen)
+	if index >= count {
+		return nil, fmt.Errorf("Unable to return subnet %d of %s: it only contains %d /%d subnets", index, ipv4.Network(), count, newPrefixLen)
+	}
+
+	size := uint64(1) << uint(IPv4len*8-newPrefixLen)
+	return IPv4Addr{
+		Address: IPv4Address(uint32(ipv4.NetworkAddress()) + uint32(index*size)),
+		Mask:    IPv4Mask(ipv4HostMaskLen(newPrefixLen)),
+	}, nil
+}
+
+// Supernet returns the network with a prefix length of prefixLen that contains
+// the IPv4Addr's network (e.g. Supernet(16) on "10.1.2.0/24" returns
+// "10.1.0.0/16").  prefixLen must not be longer than the IPv4Addr's prefix
+// length.
+func (ipv4 IPv4Addr) Supernet(prefixLen int) (IPAddr, error) {
+	ones, err := ipv4.prefixLen()
+	if err != nil {
+		return nil, err
+	}
+
+	if prefixLen < 0 || prefixLen > ones {
+		return nil, fmt.Errorf("Unable to return the /%d supernet of %s: %w, prefix length must be between 0 and %d", prefixLen, ipv4, ErrInvalidMask, ones)
+	}
+
+	mask := ipv4HostMaskLen(prefixLen)
+	return IPv4Addr{
+		Address: IPv4Address(uint32(ipv4.Address) & mask),
+		Mask:    IPv4Mask(mask),
+	}, nil
+}
+
+// Sibling returns the other network of the same size that shares the
+// IPv4Addr's immediate supernet (e.g. Sibling() on "10.0.1.0/24" returns
+// "10.0.0.0/24").  A /0 has no sibling.
+func (ipv4 IPv4Addr) Sibling() (IPAddr, error) {
+	ones, err := ipv4.prefixLen()
+	if err != nil {
+		return nil, err
+	}
+
+	if ones == 0 {
+		return nil, fmt.Errorf("Unable to return the sibling of %s: a /0 has no supernet", ipv4)
+	}
+
+	bit := uint32(1) << uint(IPv4len*8-ones)
+	return IPv4Addr{
+		Address: IPv4Address(uint32(ipv4.NetworkAddress()) ^ bit),
+		Mask:    ipv4.Mask,
+	}, nil
+}
+
+// checkSplit returns the prefix length of the IPv4Addr, or an error if its
+// network can not be split into subnets with a prefix length of newPrefixLen.
+func (ipv4 IPv4Addr) checkSplit(newPrefixLen int) (int, error) {
+	ones, err := ipv4.prefixLen()
+	if err != nil {
+		return 0, err
+	}
+
+	if newPrefixLen < ones || newPrefixLen > IPv4len*8 {
+		return 0, fmt.Errorf("Unable to split %s into /%d subnets: %w, prefix length must be between %d and %d", ipv4.Network(), newPrefixLen, ErrInvalidMask, ones, IPv4len*8)
+	}
+
+	return ones, nil
+}
+
+// prefixLen returns the prefix length of the IPv4Addr, or an error if its mask
+// is not contiguous.
+func (ipv4 IPv4Addr) prefixLen() (int, error) {
+	ones := ipv4.Maskbits()
+	if uint32(ipv4.Mask) != ipv4HostMaskLen(ones) {
+		return 0, fmt.Errorf("Unable to use %s as a network: %w %s", ipv4, ErrInvalidMask, ipv4.NetIPMask())
+	}
+
+	return ones, nil
+}
+
+// ipv4HostMaskLen returns a uint32 with the prefixLen most significant bits
+// set.
+func ipv4HostMaskLen(prefixLen int) uint32 {
+	if prefixLen <= 0 {
+		return 0
+	}
+
+	return ^uint32(0) << uint(IPv4len*8-prefixLen)
+}
+
+// Subnets returns an iterator over the subnets of the IPv6Addr's network with
+// a prefix length of newPrefixLen, in ascending order.  Subnets are generated
+// as the iterator advances, so it is safe to split large networks (e.g. a /32
+// into /64s) as long as the caller stops early.
+func (ipv6 IPv6Addr) Subnets(newPrefixLen int) (iter.Seq[IPAddr], error) {
+	if _, err := ipv6.checkSplit(newPrefixLen); err != nil {
+		return nil, err
+	}
+
+	network := Uint128(ipv6.NetworkAddress())
+	last := ipv6.lastAddress().And(uint128Mask(newPrefixLen))
+	step := Uint128{Lo: 1}.Lsh(uint(IPv6len*8 - newPrefixLen))
+	mask := IPv6Mask(uint128Mask(newPrefixLen))
+
+	return func(yield func(IPAddr) bool) {
+		for addr := network; ; addr = addr.Add(step) {
+			if !yield(IPv6Addr{Address: IPv6Address(addr), Mask: mask, Zone: ipv6.Zone}) {
+				return
+			}
+
+			if addr == last {
+				return
+			}
+		}
+	}, nil
+}
+
+// Subnet returns the index'th subnet, starting at zero, of the IPv6Addr's
+// network with a prefix length of newPrefixLen (e.g. Subnet(64, 3) on
+// "2001:db8::/48" returns "2001:db8:0:3::/64").
+func (ipv6 IPv6Addr) Subnet(newPrefixLen int, index uint64) (IPAddr, error) {
+	prefixLen, err := ipv6.checkSplit(newPrefixLen)
+	if err != nil {
+		return nil, err
+	}
+
+	if bits := newPrefixLen - prefixLen; bits < 64 && index >= uint64(1)<<uint(bits) {
+		return nil, fmt.Errorf("Unable to return subnet %d of %s: it only contains %d /%d subnets", index, ipv6.Network(), uint64(1)<<uint(bits), newPrefixLen)
+	}
+
+	offset := Uint128{Lo: index}.Lsh(uint(IPv6len*8 - newPrefixLen))
+	return IPv6Addr{
+		Address: IPv6Address(Uint128(ipv6.NetworkAddress()).Add(offset)),
+		Mask:    IPv6Mask(uint128Mask(newPrefixLen)),
+		Zone:    ipv6.Zone,
+	}, nil
+}
+
+// Supernet returns the network with a prefix length of prefixLen that contains
+// the IPv6Addr's network (e.g. Supernet(32) on "2001:db8:1::/48" returns
+// "2001:db8::/32").  prefixLen must not be longer than the IPv6Addr's prefix
+// length.
+func (ipv6 IPv6Addr) Supernet(prefixLen int) (IPAddr, error) {
+	ones, err := ipv6.prefixLen()
+	if err != nil {
+		return nil, err
+	}
+
+	if prefixLen < 0 || prefixLen > ones {
+		return nil, fmt.Errorf("Unable to return the /%d supernet of %s: %w, prefix length must be between 0 and %d", prefixLen, ipv6, ErrInvalidMask, ones)
+	}
+
+	mask := uint128Mask(prefixLen)
+	return IPv6Addr{
+		Address: IPv6Address(Uint128(ipv6.Address).And(mask)),
+		Mask:    IPv6Mask(mask),
+		Zone:    ipv6.Zone,
+	}, nil
+}
+
+// Sibling returns the other network of the same size that shares the
+// IPv6Addr's immediate supernet (e.g. Sibling() on "2001:db8:1::/48" returns
+// "2001:db8::/48").  A /0 has no sibling.
+func (ipv6 IPv6Addr) Sibling() (IPAddr, error) {
+	ones, err := ipv6.prefixLen()
+	if err != nil {
+		return nil, err
+	}
+
+	if ones == 0 {
+		return nil, fmt.Errorf("Unable to return the sibling of %s: a /0 has no supernet", ipv6)
+	}
+
+	bit := Uint128{Lo: 1}.Lsh(uint(IPv6len*8 - ones))
+	return IPv6Addr{
+		Address: IPv6Address(Uint128(ipv6.NetworkAddress()).Xor(bit)),
+		Mask:    ipv6.Mask,
+		Zone:    ipv6.Zone,
+	}, nil
+}
+
+// IsNibbleAligned returns true if the IPv6Addr's prefix length is a multiple
+// of 4, i.e. the network falls on a hexadecimal digit boundary and maps to a
+// single ip6.arpa zone (e.g. a /48 or a /52, but not a /50).
+func (ipv6 IPv6Addr) IsNibbleAligned() bool {
+	return ipv6.Maskbits()%4 == 0
+}
+
+// NibbleSupernet returns the smallest nibble-aligned network that contains the
+// IPv6Addr's network (e.g. NibbleSupernet() on "2001:db8::/50" returns
+// "2001:db8::/48").  A nibble-aligned network is returned as is.
+func (ipv6 IPv6Addr) NibbleSupernet() (IPAddr, error) {
+	ones, err := ipv6.prefixLen()
+	if err != nil {
+		return nil, err
+	}
+
+	return ipv6.Supernet(ones - ones%4)
+}
+
+// NibbleSubnets returns an iterator over the subnets of the IPv6Addr's network
+// at the next nibble boundary (e.g. NibbleSubnets() on "2001:db8::/48" yields
+// the 16 /52s and on "2001:db8::/50" yields the 4 /52s).
+func (ipv6 IPv6Addr) NibbleSubnets() (iter.Seq[IPAddr], error) {
+	ones, err := ipv6.prefixLen()
+	if err != nil {
+		return nil, err
+	}
+
+	return ipv6.Subnets(ones + 4 - ones%4)
+}
+
+// checkSplit returns the prefix length of the IPv6Addr, or an error if its
+// network can not be split into subnets with a prefix length of newPrefixLen.
+func (ipv6 IPv6Addr) checkSplit(newPrefixLen int) (int, error) {
+	ones, err := ipv6.prefixLen()
+	if err != nil {
+		return 0, err
+	}
+
+	if newPrefixLen < ones || newPrefixLen > IPv6len*8 {
+		return 0, fmt.Errorf("Unable to split %s into /%d subnets: %w, prefix length must be between %d and %d", ipv6.Network(), newPrefixLen, ErrInvalidMask, ones, IPv6len*8)
+	}
+
+	return ones, nil
+}
+
+// prefixLen returns the prefix length of the IPv6Addr, or an error if its mask
+// is not contiguous.
+func (ipv6 IPv6Addr) prefixLen() (int, error) {
+	ones := ipv6.Maskbits()
+	if Uint128(ipv6.Mask) != uint128Mask(ones) {
+		return 0, fmt.Errorf("Unable to use %s as a network: %w %s", ipv6, ErrInvalidMask, ipv6.NetIPMask())
+	}
+
+	return ones, nil
+}
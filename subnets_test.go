@@ -0,0 +1,265 @@
+package sockaddr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+// subnetter is implemented by IPv4Addr and IPv6Addr.
+type subnetter interface {
+	sockaddr.IPAddr
+	Subnet(newPrefixLen int, index uint64) (sockaddr.IPAddr, error)
+	Supernet(prefixLen int) (sockaddr.IPAddr, error)
+	Sibling() (sockaddr.IPAddr, error)
+}
+
+func TestIPAddr_Subnets(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     sockaddr.IPAddr
+		prefixLen int
+		limit     int
+		output    []string
+		fail      bool
+	}{
+		{
+			name:      "ipv4 /24 into /26",
+			input:     sockaddr.MustIPv4Addr("10.0.0.77/24"),
+			prefixLen: 26,
+			output:    []string{"10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26", "10.0.0.192/26"},
+		},
+		{
+			name:      "ipv4 same size",
+			input:     sockaddr.MustIPv4Addr("10.0.0.0/24"),
+			prefixLen: 24,
+			output:    []string{"10.0.0.0/24"},
+		},
+		{
+			name:      "ipv4 /0 into /32",
+			input:     sockaddr.MustIPv4Addr("0.0.0.0/0"),
+			prefixLen: 32,
+			limit:     2,
+			output:    []string{"0.0.0.0", "0.0.0.1"},
+		},
+		{
+			name:      "ipv4 /30 into /32",
+			input:     sockaddr.MustIPv4Addr("255.255.255.252/30"),
+			prefixLen: 32,
+			output:    []string{"255.255.255.252", "255.255.255.253", "255.255.255.254", "255.255.255.255"},
+		},
+		{name: "ipv4 shorter prefix", input: sockaddr.MustIPv4Addr("10.0.0.0/24"), prefixLen: 23, fail: true},
+		{name: "ipv4 too long", input: sockaddr.MustIPv4Addr("10.0.0.0/24"), prefixLen: 33, fail: true},
+		{
+			name:      "ipv6 /48 into /50",
+			input:     sockaddr.MustIPv6Addr("2001:db8::/48"),
+			prefixLen: 50,
+			output:    []string{"2001:db8::/50", "2001:db8:0:4000::/50", "2001:db8:0:8000::/50", "2001:db8:0:c000::/50"},
+		},
+		{
+			name:      "ipv6 /32 into /64 lazy",
+			input:     sockaddr.MustIPv6Addr("2001:db8::/32"),
+			prefixLen: 64,
+			limit:     2,
+			output:    []string{"2001:db8::/64", "2001:db8:0:1::/64"},
+		},
+		{
+			name:      "ipv6 /126 into /128",
+			input:     sockaddr.MustIPv6Addr("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/126"),
+			prefixLen: 128,
+			output:    []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffd", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		},
+		{name: "ipv6 shorter prefix", input: sockaddr.MustIPv6Addr("2001:db8::/48"), prefixLen: 32, fail: true},
+		{name: "ipv6 too long", input: sockaddr.MustIPv6Addr("2001:db8::/48"), prefixLen: 129, fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var seq func(func(sockaddr.IPAddr) bool)
+			var err error
+			switch v := test.input.(type) {
+			case sockaddr.IPv4Addr:
+				seq, err = v.Subnets(test.prefixLen)
+			case sockaddr.IPv6Addr:
+				seq, err = v.Subnets(test.prefixLen)
+			}
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected splitting %v into /%d to fail", test.input, test.prefixLen)
+				}
+				if !errors.Is(err, sockaddr.ErrInvalidMask) {
+					t.Fatalf("expected ErrInvalidMask, received %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to split %v into /%d: %v", test.input, test.prefixLen, err)
+			}
+
+			var output []string
+			for subnet := range seq {
+				output = append(output, subnet.String())
+				if test.limit > 0 && len(output) == test.limit {
+					break
+				}
+			}
+
+			if !reflect.DeepEqual(output, test.output) {
+				t.Fatalf("expected %q, received %q", test.output, output)
+			}
+
+			// Subnet() agrees with Subnets().
+			for i, expected := range test.output {
+				subnet, err := test.input.(subnetter).Subnet(test.prefixLen, uint64(i))
+				if err != nil || subnet.String() != expected {
+					t.Fatalf("Subnet(%d, %d): expected %q, received %v, %v", test.prefixLen, i, expected, subnet, err)
+				}
+			}
+		})
+	}
+}
+
+func TestIPAddr_Subnet(t *testing.T) {
+	tests := []struct {
+		input     subnetter
+		prefixLen int
+		index     uint64
+		output    string
+		fail      bool
+	}{
+		{input: sockaddr.MustIPv4Addr("10.0.0.0/24"), prefixLen: 26, index: 2, output: "10.0.0.128/26"},
+		{input: sockaddr.MustIPv4Addr("10.0.0.0/24"), prefixLen: 26, index: 4, fail: true},
+		{input: sockaddr.MustIPv4Addr("0.0.0.0/0"), prefixLen: 32, index: 1<<32 - 1, output: "255.255.255.255"},
+		{input: sockaddr.MustIPv4Addr("0.0.0.0/0"), prefixLen: 32, index: 1 << 32, fail: true},
+		{input: sockaddr.MustIPv6Addr("2001:db8::/48"), prefixLen: 64, index: 3, output: "2001:db8:0:3::/64"},
+		{input: sockaddr.MustIPv6Addr("2001:db8::/48"), prefixLen: 64, index: 1 << 16, fail: true},
+		{input: sockaddr.MustIPv6Addr("::/0"), prefixLen: 128, index: 1<<64 - 1, output: "::ffff:ffff:ffff:ffff"},
+		{input: sockaddr.MustIPv6Addr("fe80::/10%eth0"), prefixLen: 64, index: 1, output: "fe80:0:0:1::%eth0/64"},
+	}
+
+	for _, test := range tests {
+		subnet, err := test.input.Subnet(test.prefixLen, test.index)
+		if test.fail {
+			if err == nil {
+				t.Errorf("expected Subnet(%d, %d) on %v to fail, received %v", test.prefixLen, test.index, test.input, subnet)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Subnet(%d, %d) on %v: %v", test.prefixLen, test.index, test.input, err)
+			continue
+		}
+		if subnet.String() != test.output {
+			t.Errorf("Subnet(%d, %d) on %v: expected %q, received %q", test.prefixLen, test.index, test.input, test.output, subnet)
+		}
+	}
+}
+
+func TestIPAddr_SupernetSibling(t *testing.T) {
+	tests := []struct {
+		input     subnetter
+		prefixLen int
+		supernet  string
+		sibling   string
+	}{
+		{input: sockaddr.MustIPv4Addr("10.1.2.3/24"), prefixLen: 16, supernet: "10.1.0.0/16", sibling: "10.1.3.0/24"},
+		{input: sockaddr.MustIPv4Addr("10.1.2.3"), prefixLen: 0, supernet: "0.0.0.0/0", sibling: "10.1.2.2"},
+		{input: sockaddr.MustIPv4Addr("128.0.0.0/1"), prefixLen: 1, supernet: "128.0.0.0/1", sibling: "0.0.0.0/1"},
+		{input: sockaddr.MustIPv6Addr("2001:db8:1::/48"), prefixLen: 32, supernet: "2001:db8::/32", sibling: "2001:db8::/48"},
+		{input: sockaddr.MustIPv6Addr("::1"), prefixLen: 127, supernet: "::/127", sibling: "::"},
+	}
+
+	for _, test := range tests {
+		supernet, err := test.input.Supernet(test.prefixLen)
+		if err != nil || supernet.String() != test.supernet {
+			t.Errorf("Supernet(%d) on %v: expected %q, received %v, %v", test.prefixLen, test.input, test.supernet, supernet, err)
+		}
+
+		if _, err := test.input.Supernet(test.input.Maskbits() + 1); err == nil && test.input.Maskbits() < 128 {
+			t.Errorf("expected a longer supernet of %v to fail", test.input)
+		}
+
+		sibling, err := test.input.Sibling()
+		if err != nil || sibling.String() != test.sibling {
+			t.Errorf("Sibling() on %v: expected %q, received %v, %v", test.input, test.sibling, sibling, err)
+		}
+	}
+
+	for _, input := range []subnetter{sockaddr.MustIPv4Addr("0.0.0.0/0"), sockaddr.MustIPv6Addr("::/0")} {
+		if _, err := input.Sibling(); err == nil {
+			t.Errorf("expected Sibling() on %v to fail", input)
+		}
+	}
+
+	noncanonical := sockaddr.MustIPv4Addr("10.0.0.0/8")
+	noncanonical.Mask = 0xff00ff00
+	if _, err := noncanonical.Supernet(8); !errors.Is(err, sockaddr.ErrInvalidMask) {
+		t.Errorf("expected a non-contiguous mask to fail with ErrInvalidMask, received %v", err)
+	}
+}
+
+func TestIPv6Addr_Nibble(t *testing.T) {
+	tests := []struct {
+		input    string
+		aligned  bool
+		supernet string
+		subnets  []string
+	}{
+		{
+			input:    "2001:db8::/48",
+			aligned:  true,
+			supernet: "2001:db8::/48",
+			subnets:  []string{"2001:db8::/52", "2001:db8:0:1000::/52"},
+		},
+		{
+			input:    "2001:db8:0:4000::/50",
+			aligned:  false,
+			supernet: "2001:db8::/48",
+			subnets:  []string{"2001:db8:0:4000::/52", "2001:db8:0:5000::/52", "2001:db8:0:6000::/52", "2001:db8:0:7000::/52"},
+		},
+		{
+			input:    "2001:db8::/127",
+			aligned:  false,
+			supernet: "2001:db8::/124",
+			subnets:  []string{"2001:db8::", "2001:db8::1"},
+		},
+	}
+
+	for _, test := range tests {
+		ipv6 := sockaddr.MustIPv6Addr(test.input)
+		if ipv6.IsNibbleAligned() != test.aligned {
+			t.Errorf("IsNibbleAligned() on %s: expected %v", test.input, test.aligned)
+		}
+
+		supernet, err := ipv6.NibbleSupernet()
+		if err != nil || supernet.String() != test.supernet {
+			t.Errorf("NibbleSupernet() on %s: expected %q, received %v, %v", test.input, test.supernet, supernet, err)
+		}
+
+		seq, err := ipv6.NibbleSubnets()
+		if err != nil {
+			t.Fatalf("NibbleSubnets() on %s: %v", test.input, err)
+		}
+
+		var subnets []string
+		count := 0
+		for subnet := range seq {
+			if len(subnets) < len(test.subnets) {
+				subnets = append(subnets, subnet.String())
+			}
+			count++
+		}
+		if !reflect.DeepEqual(subnets, test.subnets) {
+			t.Errorf("NibbleSubnets() on %s: expected %q, received %q", test.input, test.subnets, subnets)
+		}
+		if test.aligned && count != 16 {
+			t.Errorf("NibbleSubnets() on %s: expected 16 subnets, received %d", test.input, count)
+		}
+	}
+
+	if _, err := sockaddr.MustIPv6Addr("::1").NibbleSubnets(); err == nil {
+		t.Errorf("expected NibbleSubnets() on a /128 to fail")
+	}
+}
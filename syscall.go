@@ -0,0 +1,114 @@
+// +build !plan9
+
+package sockaddr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+)
+
+// ToSyscall returns the syscall.SockaddrInet4 (i.e. `sockaddr_in`) for the
+// IPv4Addr for use with syscall.Bind(), syscall.Connect() and friends.  The
+// netmask is not part of a `sockaddr_in` and is ignored.  The fields of
+// golang.org/x/sys/unix.SockaddrInet4 are identical.
+func (ipv4 IPv4Addr) ToSyscall() (syscall.Sockaddr, error) {
+	sa := &syscall.SockaddrInet4{Port: int(ipv4.Port)}
+	binary.BigEndian.PutUint32(sa.Addr[:], uint32(ipv4.Address))
+	return sa, nil
+}
+
+// ToSyscall returns the syscall.SockaddrInet6 (i.e. `sockaddr_in6`) for the
+// IPv6Addr for use with syscall.Bind(), syscall.Connect() and friends.  The
+// zone is converted to a scope ID, either by looking up the index of the
+// interface it names or by parsing it as a number.  The netmask is not part
+// of a `sockaddr_in6` and is ignored.
+func (ipv6 IPv6Addr) ToSyscall() (syscall.Sockaddr, error) {
+	zoneID, err := zoneToScopeID(ipv6.Zone)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to convert %s to a sockaddr_in6: %w", ipv6, err)
+	}
+
+	sa := &syscall.SockaddrInet6{Port: int(ipv6.Port), ZoneId: zoneID}
+	addr := Uint128(ipv6.Address).Bytes()
+	copy(sa.Addr[:], addr[:])
+	return sa, nil
+}
+
+// ToSyscall returns the syscall.SockaddrUnix (i.e. `sockaddr_un`) for the
+// UnixSock for use with syscall.Bind(), syscall.Connect() and friends.
+// Abstract UNIX sockets are only supported on Linux.
+func (us UnixSock) ToSyscall() (syscall.Sockaddr, error) {
+	if us.IsAbstract() && !abstractUnixSockSupported {
+		return nil, fmt.Errorf("Unable to convert %s to a sockaddr_un: abstract UNIX sockets are not supported on this platform", us)
+	}
+
+	return &syscall.SockaddrUnix{Name: us.path}, nil
+}
+
+// FromSyscall creates an IPv4Addr, IPv6Addr or UnixSock from a
+// syscall.SockaddrInet4, syscall.SockaddrInet6 or syscall.SockaddrUnix
+// (e.g. as returned by syscall.Getsockname() or syscall.Accept()).  IPv4 and
+// IPv6 addresses are host addresses.  An IPv6 scope ID is converted to the
+// name of the interface with that index, or to a number if there is no such
+// interface.  Unnamed UNIX sockets are returned as a UnixSock with an empty
+// path.
+func FromSyscall(sa syscall.Sockaddr) (SockAddr, error) {
+	switch v := sa.(type) {
+	case *syscall.SockaddrInet4:
+		return IPv4Addr{
+			Address: IPv4Address(binary.BigEndian.Uint32(v.Addr[:])),
+			Mask:    IPv4HostMask,
+			Port:    IPPort(v.Port),
+		}, nil
+	case *syscall.SockaddrInet6:
+		return IPv6Addr{
+			Address: IPv6Address(Uint128FromBytes(v.Addr)),
+			Mask:    ipv6HostMask,
+			Port:    IPPort(v.Port),
+			Zone:    scopeIDToZone(v.ZoneId),
+		}, nil
+	case *syscall.SockaddrUnix:
+		if v.Name == "" {
+			return UnixSock{}, nil
+		}
+		return NewUnixSock(v.Name)
+	default:
+		return nil, fmt.Errorf("Unable to convert unsupported %T to a SockAddr", sa)
+	}
+}
+
+// zoneToScopeID returns the scope ID for an IPv6 zone, which is either the
+// name of an interface or a number.
+func zoneToScopeID(zone string) (uint32, error) {
+	if zone == "" {
+		return 0, nil
+	}
+
+	if id, err := strconv.ParseUint(zone, 10, 32); err == nil {
+		return uint32(id), nil
+	}
+
+	ifi, err := net.InterfaceByName(zone)
+	if err != nil {
+		return 0, fmt.Errorf("invalid zone %+q: %w", zone, err)
+	}
+
+	return uint32(ifi.Index), nil
+}
+
+// scopeIDToZone returns the name of the interface with the index id, or id
+// as a number if there is no such interface.
+func scopeIDToZone(id uint32) string {
+	if id == 0 {
+		return ""
+	}
+
+	if ifi, err := net.InterfaceByIndex(int(id)); err == nil {
+		return ifi.Name
+	}
+
+	return strconv.FormatUint(uint64(id), 10)
+}
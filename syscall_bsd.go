@@ -0,0 +1,15 @@
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package sockaddr
+
+import "unsafe"
+
+// abstractUnixSockSupported is true if the platform supports abstract UNIX
+// sockets.
+const abstractUnixSockSupported = false
+
+// setRawSockaddrLen sets the length field of a raw sockaddr, which is the
+// first byte of every BSD sockaddr.
+func setRawSockaddrLen(p unsafe.Pointer, n int) {
+	*(*uint8)(p) = uint8(n)
+}
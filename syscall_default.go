@@ -0,0 +1,7 @@
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!plan9
+
+package sockaddr
+
+// abstractUnixSockSupported is true if the platform supports abstract UNIX
+// sockets.
+const abstractUnixSockSupported = false
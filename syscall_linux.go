@@ -0,0 +1,11 @@
+package sockaddr
+
+import "unsafe"
+
+// abstractUnixSockSupported is true if the platform supports abstract UNIX
+// sockets.
+const abstractUnixSockSupported = true
+
+// setRawSockaddrLen sets the length field of a raw sockaddr.  Linux sockaddrs
+// have no length field.
+func setRawSockaddrLen(p unsafe.Pointer, n int) {}
@@ -0,0 +1,134 @@
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package sockaddr
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// ToRawSockaddr returns the raw kernel sockaddr for an IPv4Addr, IPv6Addr or
+// UnixSock, and its length, for use with system calls that are not wrapped by
+// the syscall package.  See ToSyscall() for how each type is converted.
+func ToRawSockaddr(sa SockAddr) (*syscall.RawSockaddrAny, uint32, error) {
+	var rsa syscall.RawSockaddrAny
+	p := unsafe.Pointer(&rsa)
+
+	switch v := sa.(type) {
+	case IPv4Addr:
+		raw := (*syscall.RawSockaddrInet4)(p)
+		raw.Family = syscall.AF_INET
+		putRawPort(&raw.Port, v.Port)
+		sys, _ := v.ToSyscall()
+		raw.Addr = sys.(*syscall.SockaddrInet4).Addr
+		setRawSockaddrLen(p, syscall.SizeofSockaddrInet4)
+		return &rsa, syscall.SizeofSockaddrInet4, nil
+	case IPv6Addr:
+		sys, err := v.ToSyscall()
+		if err != nil {
+			return nil, 0, err
+		}
+		sa6 := sys.(*syscall.SockaddrInet6)
+
+		raw := (*syscall.RawSockaddrInet6)(p)
+		raw.Family = syscall.AF_INET6
+		putRawPort(&raw.Port, v.Port)
+		raw.Addr = sa6.Addr
+		raw.Scope_id = sa6.ZoneId
+		setRawSockaddrLen(p, syscall.SizeofSockaddrInet6)
+		return &rsa, syscall.SizeofSockaddrInet6, nil
+	case UnixSock:
+		if _, err := v.ToSyscall(); err != nil {
+			return nil, 0, err
+		}
+
+		raw := (*syscall.RawSockaddrUnix)(p)
+		raw.Family = syscall.AF_UNIX
+		path := v.path
+		n := len(path)
+		if v.IsAbstract() {
+			// The name of an abstract socket starts with a NUL byte instead
+			// of `@` and is not NUL terminated.
+			path = "\x00" + path[1:]
+		} else if n > 0 {
+			n++
+		}
+		if n > len(raw.Path) {
+			return nil, 0, fmt.Errorf("Unable to convert %s to a sockaddr_un: path too long", v)
+		}
+		for i := 0; i < len(path); i++ {
+			raw.Path[i] = int8(path[i])
+		}
+
+		sl := int(unsafe.Offsetof(raw.Path)) + n
+		setRawSockaddrLen(p, sl)
+		return &rsa, uint32(sl), nil
+	default:
+		return nil, 0, fmt.Errorf("Unable to convert unsupported type %T to a raw sockaddr", sa)
+	}
+}
+
+// FromRawSockaddr creates an IPv4Addr, IPv6Addr or UnixSock from a raw kernel
+// sockaddr of length n (e.g. as returned by the accept(2) or getsockname(2)
+// system calls).  See FromSyscall() for how each family is converted.
+func FromRawSockaddr(rsa *syscall.RawSockaddrAny, n uint32) (SockAddr, error) {
+	p := unsafe.Pointer(rsa)
+
+	switch rsa.Addr.Family {
+	case syscall.AF_INET:
+		if n < syscall.SizeofSockaddrInet4 {
+			return nil, fmt.Errorf("Unable to convert a raw sockaddr_in: invalid length %d", n)
+		}
+		raw := (*syscall.RawSockaddrInet4)(p)
+		return FromSyscall(&syscall.SockaddrInet4{Port: int(getRawPort(&raw.Port)), Addr: raw.Addr})
+	case syscall.AF_INET6:
+		if n < syscall.SizeofSockaddrInet6 {
+			return nil, fmt.Errorf("Unable to convert a raw sockaddr_in6: invalid length %d", n)
+		}
+		raw := (*syscall.RawSockaddrInet6)(p)
+		return FromSyscall(&syscall.SockaddrInet6{Port: int(getRawPort(&raw.Port)), ZoneId: raw.Scope_id, Addr: raw.Addr})
+	case syscall.AF_UNIX:
+		raw := (*syscall.RawSockaddrUnix)(p)
+		offset := uint32(unsafe.Offsetof(raw.Path))
+		if n > offset+uint32(len(raw.Path)) {
+			return nil, fmt.Errorf("Unable to convert a raw sockaddr_un: invalid length %d", n)
+		}
+		if n <= offset {
+			return UnixSock{}, nil
+		}
+
+		path := make([]byte, 0, n-offset)
+		for _, c := range raw.Path[:n-offset] {
+			path = append(path, byte(c))
+		}
+
+		if path[0] == 0 && abstractUnixSockSupported {
+			path[0] = '@'
+		} else {
+			for i, c := range path {
+				if c == 0 {
+					path = path[:i]
+					break
+				}
+			}
+		}
+
+		return FromSyscall(&syscall.SockaddrUnix{Name: string(path)})
+	default:
+		return nil, fmt.Errorf("Unable to convert a raw sockaddr of unsupported family %d", rsa.Addr.Family)
+	}
+}
+
+// putRawPort stores port in network byte order.
+func putRawPort(dst *uint16, port IPPort) {
+	b := (*[2]byte)(unsafe.Pointer(dst))
+	b[0] = byte(port >> 8)
+	b[1] = byte(port)
+}
+
+// getRawPort returns the port stored in network byte order.
+func getRawPort(src *uint16) IPPort {
+	b := (*[2]byte)(unsafe.Pointer(src))
+	return IPPort(b[0])<<8 | IPPort(b[1])
+}
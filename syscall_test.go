@@ -0,0 +1,201 @@
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package sockaddr_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+	"unsafe"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestSockAddr_ToSyscall(t *testing.T) {
+	tests := []struct {
+		name string
+		in   sockaddr.SockAddr
+		out  syscall.Sockaddr
+	}{
+		{
+			name: "ipv4",
+			in:   sockaddr.MustIPv4Addr("192.168.1.10:8500"),
+			out:  &syscall.SockaddrInet4{Port: 8500, Addr: [4]byte{192, 168, 1, 10}},
+		},
+		{
+			name: "ipv4 network",
+			in:   sockaddr.MustIPv4Addr("10.0.0.0/8"),
+			out:  &syscall.SockaddrInet4{Addr: [4]byte{10}},
+		},
+		{
+			name: "ipv6",
+			in:   sockaddr.MustIPv6Addr("[2001:db8::1]:53"),
+			out:  &syscall.SockaddrInet6{Port: 53, Addr: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}},
+		},
+		{
+			name: "ipv6 numeric zone",
+			in:   sockaddr.MustIPv6Addr("[fe80::1%4000000]:53"),
+			out:  &syscall.SockaddrInet6{Port: 53, ZoneId: 4000000, Addr: [16]byte{0xfe, 0x80, 15: 1}},
+		},
+		{
+			name: "unix",
+			in:   sockaddr.MustUnixSock("/tmp/agent.sock"),
+			out:  &syscall.SockaddrUnix{Name: "/tmp/agent.sock"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sa, err := test.in.(interface {
+				ToSyscall() (syscall.Sockaddr, error)
+			}).ToSyscall()
+			if err != nil {
+				t.Fatalf("ToSyscall: %v", err)
+			}
+
+			switch want := test.out.(type) {
+			case *syscall.SockaddrInet4:
+				if got := sa.(*syscall.SockaddrInet4); got.Port != want.Port || got.Addr != want.Addr {
+					t.Fatalf("expected %+v, received %+v", want, got)
+				}
+			case *syscall.SockaddrInet6:
+				if got := sa.(*syscall.SockaddrInet6); got.Port != want.Port || got.ZoneId != want.ZoneId || got.Addr != want.Addr {
+					t.Fatalf("expected %+v, received %+v", want, got)
+				}
+			case *syscall.SockaddrUnix:
+				if got := sa.(*syscall.SockaddrUnix); got.Name != want.Name {
+					t.Fatalf("expected %+v, received %+v", want, got)
+				}
+			}
+
+			back, err := sockaddr.FromSyscall(sa)
+			if err != nil {
+				t.Fatalf("FromSyscall: %v", err)
+			}
+
+			// Networks come back as host addresses.
+			expected := test.in
+			if ipAddr, ok := expected.(sockaddr.IPAddr); ok {
+				expected = ipAddr.Host()
+			}
+			if !back.Equal(expected) {
+				t.Fatalf("FromSyscall: expected %v, received %v", expected, back)
+			}
+
+			rsa, n, err := sockaddr.ToRawSockaddr(test.in)
+			if err != nil {
+				t.Fatalf("ToRawSockaddr: %v", err)
+			}
+
+			raw, err := sockaddr.FromRawSockaddr(rsa, n)
+			if err != nil {
+				t.Fatalf("FromRawSockaddr: %v", err)
+			}
+			if !raw.Equal(expected) {
+				t.Fatalf("FromRawSockaddr: expected %v, received %v", expected, raw)
+			}
+		})
+	}
+
+	if _, err := sockaddr.MustIPv6Addr("fe80::1%no-such-interface0").ToSyscall(); err == nil {
+		t.Fatalf("expected an unknown zone to fail")
+	}
+
+	if _, err := sockaddr.FromSyscall(nil); err == nil {
+		t.Fatalf("expected a nil Sockaddr to fail")
+	}
+}
+
+func TestSockAddr_SyscallBind(t *testing.T) {
+	tests := []struct {
+		name   string
+		family int
+		sotype int
+		in     sockaddr.SockAddr
+	}{
+		{"ipv4", syscall.AF_INET, syscall.SOCK_STREAM, sockaddr.MustIPv4Addr("127.0.0.1:0")},
+		{"unix", syscall.AF_UNIX, syscall.SOCK_STREAM, sockaddr.MustUnixSock(filepath.Join(t.TempDir(), "bind.sock"))},
+	}
+	if runtime.GOOS == "linux" {
+		tests = append(tests, struct {
+			name   string
+			family int
+			sotype int
+			in     sockaddr.SockAddr
+		}{"abstract", syscall.AF_UNIX, syscall.SOCK_STREAM, sockaddr.MustUnixSock("@go-sockaddr-syscall-test")})
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fd, err := syscall.Socket(test.family, test.sotype, 0)
+			if err != nil {
+				t.Skipf("unable to create socket: %v", err)
+			}
+			defer syscall.Close(fd)
+
+			sa, err := test.in.(interface {
+				ToSyscall() (syscall.Sockaddr, error)
+			}).ToSyscall()
+			if err != nil {
+				t.Fatalf("ToSyscall: %v", err)
+			}
+
+			if err := syscall.Bind(fd, sa); err != nil {
+				t.Fatalf("unable to bind %v: %v", test.in, err)
+			}
+
+			bound, err := syscall.Getsockname(fd)
+			if err != nil {
+				t.Fatalf("unable to get socket name: %v", err)
+			}
+
+			got, err := sockaddr.FromSyscall(bound)
+			if err != nil {
+				t.Fatalf("FromSyscall: %v", err)
+			}
+
+			if got.Type() != test.in.Type() {
+				t.Fatalf("expected a %s, received %v", test.in.Type(), got)
+			}
+			if us, ok := test.in.(sockaddr.UnixSock); ok && !got.Equal(us) {
+				t.Fatalf("expected %v, received %v", us, got)
+			}
+			if ipv4, ok := got.(sockaddr.IPv4Addr); ok && ipv4.Port == 0 {
+				t.Fatalf("expected a port to be assigned, received %v", ipv4)
+			}
+		})
+	}
+}
+
+func TestSockAddr_RawAbstract(t *testing.T) {
+	us := sockaddr.MustUnixSock("@agent")
+	rsa, n, err := sockaddr.ToRawSockaddr(us)
+	if runtime.GOOS != "linux" {
+		if err == nil {
+			t.Fatalf("expected abstract sockets to be unsupported on %s", runtime.GOOS)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("ToRawSockaddr: %v", err)
+	}
+
+	raw := (*syscall.RawSockaddrUnix)(unsafe.Pointer(rsa))
+	if raw.Path[0] != 0 || raw.Path[1] != 'a' || n != 2+uint32(len("@agent")) {
+		t.Fatalf("unexpected raw abstract sockaddr %v (len %d)", raw.Path[:8], n)
+	}
+
+	back, err := sockaddr.FromRawSockaddr(rsa, n)
+	if err != nil {
+		t.Fatalf("FromRawSockaddr: %v", err)
+	}
+	if !back.Equal(us) {
+		t.Fatalf("expected %v, received %v", us, back)
+	}
+
+	unnamed, err := sockaddr.FromRawSockaddr(rsa, 2)
+	if err != nil || unnamed.(sockaddr.UnixSock).Path() != "" {
+		t.Fatalf("expected an unnamed UnixSock, received %v, %v", unnamed, err)
+	}
+}
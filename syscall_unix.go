@@ -0,0 +1,63 @@
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package sockaddr
+
+import (
+	"fmt"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// ToUnix returns the golang.org/x/sys/unix.SockaddrInet4 for the IPv4Addr for
+// use with unix.Bind(), unix.Connect() and friends, which do not accept the
+// syscall.Sockaddr returned by ToSyscall().  See ToSyscall().
+func (ipv4 IPv4Addr) ToUnix() (unix.Sockaddr, error) {
+	sys, err := ipv4.ToSyscall()
+	if err != nil {
+		return nil, err
+	}
+
+	return &unix.SockaddrInet4{Port: int(ipv4.Port), Addr: sys.(*syscall.SockaddrInet4).Addr}, nil
+}
+
+// ToUnix returns the golang.org/x/sys/unix.SockaddrInet6 for the IPv6Addr for
+// use with unix.Bind(), unix.Connect() and friends.  The zone is converted to
+// a scope ID as in ToSyscall().
+func (ipv6 IPv6Addr) ToUnix() (unix.Sockaddr, error) {
+	sys, err := ipv6.ToSyscall()
+	if err != nil {
+		return nil, err
+	}
+
+	sa6 := sys.(*syscall.SockaddrInet6)
+	return &unix.SockaddrInet6{Port: int(ipv6.Port), ZoneId: sa6.ZoneId, Addr: sa6.Addr}, nil
+}
+
+// ToUnix returns the golang.org/x/sys/unix.SockaddrUnix for the UnixSock for
+// use with unix.Bind(), unix.Connect() and friends.  Abstract UNIX sockets are
+// only supported on Linux.
+func (us UnixSock) ToUnix() (unix.Sockaddr, error) {
+	if _, err := us.ToSyscall(); err != nil {
+		return nil, err
+	}
+
+	return &unix.SockaddrUnix{Name: us.path}, nil
+}
+
+// FromUnix creates an IPv4Addr, IPv6Addr or UnixSock from a
+// golang.org/x/sys/unix.SockaddrInet4, unix.SockaddrInet6 or
+// unix.SockaddrUnix (e.g. as returned by unix.Getsockname() or
+// unix.Accept()).  See FromSyscall() for how each family is converted.
+func FromUnix(sa unix.Sockaddr) (SockAddr, error) {
+	switch v := sa.(type) {
+	case *unix.SockaddrInet4:
+		return FromSyscall(&syscall.SockaddrInet4{Port: v.Port, Addr: v.Addr})
+	case *unix.SockaddrInet6:
+		return FromSyscall(&syscall.SockaddrInet6{Port: v.Port, ZoneId: v.ZoneId, Addr: v.Addr})
+	case *unix.SockaddrUnix:
+		return FromSyscall(&syscall.SockaddrUnix{Name: v.Name})
+	default:
+		return nil, fmt.Errorf("Unable to convert unsupported %T to a SockAddr", sa)
+	}
+}
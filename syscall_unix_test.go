@@ -0,0 +1,129 @@
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package sockaddr_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+	"golang.org/x/sys/unix"
+)
+
+func TestSockAddr_ToUnix(t *testing.T) {
+	tests := []struct {
+		name string
+		in   sockaddr.SockAddr
+		out  unix.Sockaddr
+	}{
+		{
+			name: "ipv4",
+			in:   sockaddr.MustIPv4Addr("192.168.1.10:8500"),
+			out:  &unix.SockaddrInet4{Port: 8500, Addr: [4]byte{192, 168, 1, 10}},
+		},
+		{
+			name: "ipv6 numeric zone",
+			in:   sockaddr.MustIPv6Addr("[fe80::1%4000000]:53"),
+			out:  &unix.SockaddrInet6{Port: 53, ZoneId: 4000000, Addr: [16]byte{0xfe, 0x80, 15: 1}},
+		},
+		{
+			name: "unix",
+			in:   sockaddr.MustUnixSock("/tmp/agent.sock"),
+			out:  &unix.SockaddrUnix{Name: "/tmp/agent.sock"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sa, err := test.in.(interface {
+				ToUnix() (unix.Sockaddr, error)
+			}).ToUnix()
+			if err != nil {
+				t.Fatalf("ToUnix: %v", err)
+			}
+
+			switch want := test.out.(type) {
+			case *unix.SockaddrInet4:
+				if got := sa.(*unix.SockaddrInet4); got.Port != want.Port || got.Addr != want.Addr {
+					t.Fatalf("expected %+v, received %+v", want, got)
+				}
+			case *unix.SockaddrInet6:
+				if got := sa.(*unix.SockaddrInet6); got.Port != want.Port || got.ZoneId != want.ZoneId || got.Addr != want.Addr {
+					t.Fatalf("expected %+v, received %+v", want, got)
+				}
+			case *unix.SockaddrUnix:
+				if got := sa.(*unix.SockaddrUnix); got.Name != want.Name {
+					t.Fatalf("expected %+v, received %+v", want, got)
+				}
+			}
+
+			back, err := sockaddr.FromUnix(sa)
+			if err != nil {
+				t.Fatalf("FromUnix: %v", err)
+			}
+			if !back.Equal(test.in) {
+				t.Fatalf("FromUnix: expected %v, received %v", test.in, back)
+			}
+		})
+	}
+
+	if _, err := sockaddr.MustIPv6Addr("fe80::1%no-such-interface0").ToUnix(); err == nil {
+		t.Fatalf("expected an unknown zone to fail")
+	}
+
+	if _, err := sockaddr.FromUnix(nil); err == nil {
+		t.Fatalf("expected a nil Sockaddr to fail")
+	}
+}
+
+func TestSockAddr_UnixBind(t *testing.T) {
+	tests := []struct {
+		name   string
+		family int
+		in     sockaddr.SockAddr
+	}{
+		{"ipv4", unix.AF_INET, sockaddr.MustIPv4Addr("127.0.0.1:0")},
+		{"unix", unix.AF_UNIX, sockaddr.MustUnixSock(filepath.Join(t.TempDir(), "bind.sock"))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fd, err := unix.Socket(test.family, unix.SOCK_STREAM, 0)
+			if err != nil {
+				t.Skipf("unable to create socket: %v", err)
+			}
+			defer unix.Close(fd)
+
+			sa, err := test.in.(interface {
+				ToUnix() (unix.Sockaddr, error)
+			}).ToUnix()
+			if err != nil {
+				t.Fatalf("ToUnix: %v", err)
+			}
+
+			if err := unix.Bind(fd, sa); err != nil {
+				t.Fatalf("unable to bind %v: %v", test.in, err)
+			}
+
+			bound, err := unix.Getsockname(fd)
+			if err != nil {
+				t.Fatalf("unable to get socket name: %v", err)
+			}
+
+			got, err := sockaddr.FromUnix(bound)
+			if err != nil {
+				t.Fatalf("FromUnix: %v", err)
+			}
+
+			if got.Type() != test.in.Type() {
+				t.Fatalf("expected a %s, received %v", test.in.Type(), got)
+			}
+			if us, ok := test.in.(sockaddr.UnixSock); ok && !got.Equal(us) {
+				t.Fatalf("expected %v, received %v", us, got)
+			}
+			if ipv4, ok := got.(sockaddr.IPv4Addr); ok && ipv4.Port == 0 {
+				t.Fatalf("expected a port to be assigned, received %v", ipv4)
+			}
+		})
+	}
+}
@@ -159,12 +159,15 @@ available filtering criteria is:
     the pipe character (`|`).
   - "port": Filter IfAddrs based on an exact match of the port number (number must
     be expressed as a string)
+  - "port_range": Filter IfAddrs based on whether their port, or port range, is
+    within the given inclusive port range (e.g. `8000-8100`).  IfAddrs without a
+    port never match.
   - "rfc", "rfcs": Filter IfAddrs based on the matching RFC.  If more than one RFC
     is specified, the list of RFCs can be joined together using the pipe character (`|`).
   - "size": Filter IfAddrs based on the exact match of the mask size.
   - "type": Filter IfAddrs based on their SockAddr type.  Multiple types can be
     specified together by using the pipe character (`|`).  Valid types include:
-    `ip`, `ipv4`, `ipv6`, and `unix`.
+    `ip`, `ipv4`, `ipv6`, `unix`, `hostname`, `port_range`, and `ip_range`.
 
 Example:
 
@@ -196,6 +199,26 @@ Example:
     {{ GetPrivateInterfaces | offset "-2" | limit 1 }}
 
 
+`unmap`: Replaces IPv4-mapped (e.g. `::ffff:10.0.0.1`) and IPv4-compatible IPv6
+addresses with their embedded IPv4 address so that subsequent filters, such as
+`include "rfc"`, operate on the IPv4 address.  All other addresses are passed
+through unmodified.
+
+Example:
+
+    {{ GetAllInterfaces | unmap | include "rfc" "1918" }}
+
+
+`resolve`: Resolves a hostname, and an optional port, using the system's
+resolver and returns a list with one entry per resolved IP address.  The
+entries are not associated with a network interface but can otherwise be used
+like any other list.
+
+Example:
+
+    {{ resolve "consul.service:8500" | include "type" "IPv4" | join "string" "," }}
+
+
 `math`: Perform a "math" operation on each member of the list and return new
 values.  `math` takes two arguments, the attribute to operate on and the
 operation's value.
@@ -219,6 +242,16 @@ Supported operations include:
   	of the input address depending on which network is larger
   	(e.g. 192.168.10.20/24 `"mask" "16"` will return "192.168.0.0/16" but
   	192.168.10.20/24 `"mask" "28"` will return "192.168.10.16/24").
+  - `subnet`: Splits the network into subnets and returns one of them.  The
+    value is the prefix length of the subnets and the zero-based index of the
+    subnet, separated by a comma (e.g. 10.0.0.0/24 `"subnet" "26,2"` will
+    return "10.0.0.128/26").  The index defaults to 0.
+  - `supernet`: Returns the network with the given prefix length that contains
+    the address (e.g. 10.1.2.3/24 `"supernet" "16"` will return "10.1.0.0/16").
+    The prefix length may not be longer than the network's.
+  - `sibling`: Returns the other network of the same size that shares the
+    network's immediate supernet (e.g. 10.0.1.0/24 `"sibling" ""` will return
+    "10.0.0.0/24").  The value is ignored.
 
 Example:
 
@@ -227,6 +260,7 @@ Example:
     {{ GetPrivateInterfaces | include "type" "IP" | math "network" "+2" | attr "address" }}
     {{ GetPrivateInterfaces | include "type" "IP" | math "network" "-2" | attr "address" }}
     {{ GetPrivateInterfaces | include "type" "IP" | math "mask" "24" | attr "address" }}
+    {{ GetPrivateInterfaces | include "type" "IPv4" | math "supernet" "16" | math "subnet" "24,3" | attr "string" }}
     {{ GetPrivateInterfaces | include "flags" "forwardable|up" | include "type" "IPv4" | math "network" "+2" | attr "address" }}
 
 
@@ -265,6 +299,8 @@ Example:
 `exclude` and `include` flags:
   - `broadcast`
   - `down`: Is the interface down?
+  - `eui64`: Is the IPv6 interface identifier derived from a MAC address
+    (modified EUI-64, e.g. a SLAAC address rather than a privacy address)?
   - `forwardable`: Is the IP forwardable?
   - `global unicast`
   - `interface-local multicast`
@@ -283,6 +319,12 @@ SockAddr Type:
   - `string`
   - `type`
 
+IfAddr Type:
+  - `flags`
+  - `name`
+  - `eui64`: SLAAC address derived from the interface's MAC address and the
+    IPv6 /64 network of the IfAddr
+
 IPAddr Type:
   - `address`
   - `binary`
@@ -300,12 +342,64 @@ IPAddr Type:
 IPv4Addr Type:
   - `broadcast`
   - `uint32`: unsigned integer representation of the value
+  - `reverse_dns`: PTR name of the address (e.g. `1.2.0.10.in-addr.arpa`)
+  - `reverse_zones`: reverse DNS zones that cover the network, separated by
+    spaces, using RFC 2317 classless names for networks smaller than a /24
+    (e.g. `64/26.2.0.192.in-addr.arpa`)
 
 IPv6Addr Type:
   - `uint128`: unsigned integer representation of the value
+  - `zone`: IPv6 zone of a scoped address (e.g. `eth0` for link-local addresses)
+  - `reverse_dns`: PTR name of the address in `ip6.arpa`
+  - `canonical`: the address in the RFC 5952 form (e.g. `2001:db8::1`), with
+    IPv4-mapped and `64:ff9b::/96` NAT64 addresses in mixed notation (e.g.
+    `::ffff:10.1.2.3`)
+  - `expanded`: the address with all eight groups of four hexadecimal digits
+    (e.g. `2001:0db8:0000:0000:0000:0000:0000:0001`)
+  - `mixed`: the address with its last 32 bits as a dotted-quad IPv4 address
+    (e.g. `::ffff:10.1.2.3` or `64:ff9b::192.0.2.1`)
+  - `embedded_ipv4`: IPv4 address embedded in a NAT64 address within
+    `64:ff9b::/96`, a 6to4 address or an ISATAP address (e.g. `192.0.2.33` for
+    `64:ff9b::192.0.2.33`, `2002:c000:221::1` or `fe80::5efe:192.0.2.33`)
+  - `teredo_server`, `teredo_client`, `teredo_port`, `teredo_flags`: fields of
+    a Teredo address, with the obfuscation of the client address and port
+    removed (e.g. `65.54.227.120`, `192.0.2.45`, `40000` and `0x8000` for
+    `2001:0:4136:e378:8000:63bf:3fff:fdd2`)
+  - `mac_from_eui64`: MAC address embedded in a modified EUI-64 interface
+    identifier (e.g. `00:11:22:33:44:55` for `fe80::211:22ff:fe33:4455`)
+  - `reverse_zones`: nibble-aligned reverse DNS zones that cover the network,
+    separated by spaces
 
 UnixSock Type:
   - `path`
+  - `abstract`: `true` if the socket is in the Linux abstract namespace
+  - `dir`: directory containing the socket
+  - `basename`: file name of the socket
+  - `exists`: `true` if the path exists on the file system
+  - `is_socket`: `true` if the path exists and is a socket
+  - `mode`: octal permission bits of the socket (e.g. `0755`)
+  - `owner`: numeric user ID of the owner of the socket
+
+The `dir`, `basename`, `exists`, `is_socket`, `mode` and `owner` attributes
+are empty for abstract sockets, and `mode` and `owner` are empty if the path
+does not exist.
+
+Hostname Type:
+  - `host`
+  - `port`
+
+IPPortRange Type (e.g. `10.0.0.0/8:8000-8100`), in addition to the IPAddr
+attributes of its address except `port`:
+  - `port_range`: the port range (e.g. `8000-8100`)
+  - `port_low`: the first port of the range
+  - `port_high`: the last port of the range
+
+IPRange Type (e.g. `10.0.0.10-10.0.0.200`):
+  - `first`: the first address of the range
+  - `last`: the last address of the range
+  - `size`: the number of addresses in the range
+  - `prefixes`: the minimal list of CIDRs that covers the range, separated by
+    spaces (e.g. `10.0.0.10/31 10.0.0.12/30 ...`)
 
 */
 package template
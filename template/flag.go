@@ -0,0 +1,139 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+// TemplateFlag is a flag.Value that holds a go-sockaddr template (e.g.
+// `-advertise='{{ GetPrivateIP }}'`).  The template is evaluated when the flag
+// is set, or on the first call to Value() or SockAddrs() if Lazy is set.
+type TemplateFlag struct {
+	// Lazy defers evaluating the template until its value is first used,
+	// e.g. after the network interfaces have been configured.
+	Lazy bool
+
+	// Options, if set, requires every whitespace-separated element of the
+	// template's output to be a SockAddr accepted by
+	// sockaddr.ParseSockAddr() with these options.
+	Options *sockaddr.ParseOptions
+
+	// IfAddrs, if set, is used to evaluate the template instead of the
+	// addresses available on the host.
+	IfAddrs sockaddr.IfAddrs
+
+	template  string
+	value     string
+	err       error
+	evaluated bool
+}
+
+// Get implements flag.Getter and returns the template's output, or nil if the
+// template can not be evaluated.
+func (f *TemplateFlag) Get() interface{} {
+	v, err := f.Value()
+	if err != nil {
+		return nil
+	}
+
+	return v
+}
+
+// Set implements flag.Value.  Unless Lazy is set, the template is evaluated
+// immediately and any error is returned.
+func (f *TemplateFlag) Set(s string) error {
+	f.template = s
+	f.value, f.err, f.evaluated = "", nil, false
+	if f.Lazy {
+		return nil
+	}
+
+	_, err := f.Value()
+	return err
+}
+
+// String implements flag.Value and returns the unevaluated template.
+func (f *TemplateFlag) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.template
+}
+
+// Template returns the unevaluated template.
+func (f *TemplateFlag) Template() string {
+	return f.template
+}
+
+// Type returns the name of the flag's value type for use in usage messages.
+func (f *TemplateFlag) Type() string {
+	return "template"
+}
+
+// Value evaluates the template, if it has not been evaluated yet, and returns
+// its output.
+func (f *TemplateFlag) Value() (string, error) {
+	if !f.evaluated {
+		f.value, f.err = f.evaluate()
+		f.evaluated = true
+	}
+
+	return f.value, f.err
+}
+
+// SockAddrs evaluates the template, if it has not been evaluated yet, and
+// parses each whitespace-separated element of its output as a SockAddr.
+func (f *TemplateFlag) SockAddrs() (sockaddr.SockAddrs, error) {
+	v, err := f.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.parse(v)
+}
+
+// evaluate returns the output of the template, validated against Options.
+func (f *TemplateFlag) evaluate() (string, error) {
+	var v string
+	var err error
+	if f.IfAddrs != nil {
+		v, err = ParseIfAddrs(f.template, f.IfAddrs)
+	} else {
+		v, err = Parse(f.template)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if f.Options != nil {
+		if _, err := f.parse(v); err != nil {
+			return "", err
+		}
+	}
+
+	return v, nil
+}
+
+// parse returns the SockAddr of each whitespace-separated element of v.
+func (f *TemplateFlag) parse(v string) (sockaddr.SockAddrs, error) {
+	var opts sockaddr.ParseOptions
+	if f.Options != nil {
+		opts = *f.Options
+	}
+
+	elems := strings.Fields(v)
+	sas := make(sockaddr.SockAddrs, 0, len(elems))
+	for i, elem := range elems {
+		sa, err := sockaddr.ParseSockAddr(elem, opts)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid element %d of template %+q output %+q: %w", i+1, f.template, v, err)
+		}
+
+		sas = append(sas, sa)
+	}
+
+	return sas, nil
+}
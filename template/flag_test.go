@@ -0,0 +1,141 @@
+package template_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+	socktmpl "github.com/hashicorp/go-sockaddr/template"
+)
+
+func TestTemplateFlag(t *testing.T) {
+	ifAddrs := sockaddr.IfAddrs{
+		{
+			SockAddr:  sockaddr.MustIPv4Addr("10.0.0.5/8"),
+			Interface: net.Interface{Index: 2, MTU: 1500, Name: "eth0", Flags: net.FlagUp},
+		},
+		{
+			SockAddr:  sockaddr.MustIPv6Addr("2001:db8::5/64"),
+			Interface: net.Interface{Index: 2, MTU: 1500, Name: "eth0", Flags: net.FlagUp},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		lazy    bool
+		opts    *sockaddr.ParseOptions
+		input   string
+		output  string
+		setFail bool
+		valFail bool
+	}{
+		{
+			name:   "eager",
+			input:  `{{ . | include "type" "IPv4" | join "address" " " }}`,
+			output: "10.0.0.5",
+		},
+		{
+			name:   "lazy",
+			lazy:   true,
+			input:  `{{ . | join "address" " " }}`,
+			output: "10.0.0.5 2001:db8::5",
+		},
+		{
+			name:    "eager invalid template",
+			input:   `{{`,
+			setFail: true,
+		},
+		{
+			name:    "lazy invalid template",
+			lazy:    true,
+			input:   `{{`,
+			valFail: true,
+		},
+		{
+			name:   "family allowed",
+			opts:   &sockaddr.ParseOptions{Strict: true, Families: sockaddr.TypeIP},
+			input:  `{{ . | join "address" " " }}`,
+			output: "10.0.0.5 2001:db8::5",
+		},
+		{
+			name:    "family constraint",
+			opts:    &sockaddr.ParseOptions{Strict: true, Families: sockaddr.TypeIPv4},
+			input:   `{{ . | join "address" " " }}`,
+			setFail: true,
+		},
+		{
+			name:    "not an address",
+			opts:    &sockaddr.ParseOptions{Strict: true},
+			input:   `{{ . | attr "name" }}`,
+			setFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := &socktmpl.TemplateFlag{Lazy: test.lazy, Options: test.opts, IfAddrs: ifAddrs}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(f, "advertise", "")
+
+			err := fs.Parse([]string{"-advertise", test.input})
+			if test.setFail {
+				if err == nil {
+					t.Fatalf("expected %q to fail when set", test.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to set %q: %v", test.input, err)
+			}
+
+			if f.String() != test.input {
+				t.Fatalf("expected String() %q, received %q", test.input, f.String())
+			}
+
+			v, err := f.Value()
+			if test.valFail {
+				if err == nil {
+					t.Fatalf("expected %q to fail when evaluated", test.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to evaluate %q: %v", test.input, err)
+			}
+
+			if v != test.output {
+				t.Fatalf("expected %q, received %q", test.output, v)
+			}
+		})
+	}
+
+	f := &socktmpl.TemplateFlag{
+		Options: &sockaddr.ParseOptions{Strict: true, Families: sockaddr.TypeIPv6},
+		IfAddrs: ifAddrs,
+	}
+	err := f.Set(`{{ . | join "address" " " }}`)
+	if err == nil || !strings.Contains(err.Error(), `element 1`) || !strings.Contains(err.Error(), `"10.0.0.5"`) {
+		t.Fatalf("expected the error to name the first element: %v", err)
+	}
+	var pe *sockaddr.ParseError
+	if !errors.As(err, &pe) || pe.Kind != sockaddr.KindFamily {
+		t.Fatalf("expected a family ParseError: %v", err)
+	}
+
+	f = &socktmpl.TemplateFlag{IfAddrs: ifAddrs}
+	if err := f.Set(`{{ . | join "address" " " }}`); err != nil {
+		t.Fatalf("unable to set template: %v", err)
+	}
+	sas, err := f.SockAddrs()
+	if err != nil {
+		t.Fatalf("unable to parse template output: %v", err)
+	}
+	if len(sas) != 2 || sas[0].Type() != sockaddr.TypeIPv4 || sas[1].Type() != sockaddr.TypeIPv6 {
+		t.Fatalf("unexpected SockAddrs: %v", sas)
+	}
+}
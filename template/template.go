@@ -2,10 +2,10 @@ package template
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"text/template"
 
-	"github.com/hashicorp/errwrap"
 	sockaddr "github.com/hashicorp/go-sockaddr"
 )
 
@@ -69,10 +69,15 @@ func init() {
 		"limit":  sockaddr.LimitIfAddrs,
 		"offset": sockaddr.OffsetIfAddrs,
 		"unique": sockaddr.UniqueIfAddrsBy,
+		"unmap":  sockaddr.UnmapIfAddrs,
 
 		// Misc math functions that operate on a single IfAddr input
 		"math": sockaddr.IfAddrsMath,
 
+		// Resolve a hostname and optional port into IfAddrs using the
+		// system's resolver.
+		"resolve": Resolve,
+
 		// Return a Private RFC 6890 IP address string that is attached
 		// to the default route and a forwardable address.
 		"GetPrivateIP": sockaddr.GetPrivateIP,
@@ -114,12 +119,34 @@ func Attr(selectorName string, ifAddrsRaw interface{}) (string, error) {
 	}
 }
 
+// Resolve resolves hostPort (e.g. `consul.service:8500`) using the system's
+// resolver and returns one IfAddr per resolved address.  The returned IfAddrs
+// are not associated with a network interface.
+func Resolve(hostPort string) (sockaddr.IfAddrs, error) {
+	hostname, err := sockaddr.NewHostname(hostPort)
+	if err != nil {
+		return nil, err
+	}
+
+	sas, err := hostname.Resolve(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ifAddrs := make(sockaddr.IfAddrs, 0, len(sas))
+	for _, sa := range sas {
+		ifAddrs = append(ifAddrs, sockaddr.IfAddr{SockAddr: sa})
+	}
+
+	return ifAddrs, nil
+}
+
 // Parse parses input as template input using the addresses available on the
 // host, then returns the string output if there are no errors.
 func Parse(input string) (string, error) {
 	addrs, err := sockaddr.GetAllInterfaces()
 	if err != nil {
-		return "", errwrap.Wrapf("unable to query interface addresses: {{err}}", err)
+		return "", fmt.Errorf("unable to query interface addresses: %w", err)
 	}
 
 	return ParseIfAddrs(input, addrs)
@@ -142,13 +169,13 @@ func ParseIfAddrsTemplate(input string, ifAddrs sockaddr.IfAddrs, tmplIn *templa
 		Funcs(HelperFuncs).
 		Parse(input)
 	if err != nil {
-		return "", errwrap.Wrapf(fmt.Sprintf("unable to parse template %+q: {{err}}", input), err)
+		return "", fmt.Errorf("unable to parse template %+q: %w", input, err)
 	}
 
 	var outWriter bytes.Buffer
 	err = tmpl.Execute(&outWriter, ifAddrs)
 	if err != nil {
-		return "", errwrap.Wrapf(fmt.Sprintf("unable to execute sockaddr input %+q: {{err}}", input), err)
+		return "", fmt.Errorf("unable to execute sockaddr input %+q: %w", input, err)
 	}
 
 	return outWriter.String(), nil
@@ -1,8 +1,10 @@
 package template_test
 
 import (
+	"errors"
 	"testing"
 
+	sockaddr "github.com/hashicorp/go-sockaddr"
 	socktmpl "github.com/hashicorp/go-sockaddr/template"
 )
 
@@ -17,7 +19,7 @@ func TestSockAddr_Parse(t *testing.T) {
 		{
 			name:   `basic include "name"`,
 			input:  `{{GetAllInterfaces | include "name" "lo0" | printf "%v"}}`,
-			output: `[127.0.0.1/8 {1 16384 lo0  up|loopback|multicast} ::1 {1 16384 lo0  up|loopback|multicast} fe80::1/64 {1 16384 lo0  up|loopback|multicast}]`,
+			output: `[127.0.0.1/8 {1 16384 lo0  up|loopback|multicast} ::1 {1 16384 lo0  up|loopback|multicast} fe80::1%lo0/64 {1 16384 lo0  up|loopback|multicast}]`,
 		},
 		{
 			name:   "invalid input",
@@ -48,12 +50,12 @@ func TestSockAddr_Parse(t *testing.T) {
 		{
 			name:   `include "type" "IPv6`,
 			input:  `{{. | include "type" "IPv6" | include "name" "^lo0$" | sort "address" }}`,
-			output: `[::1 {1 16384 lo0  up|loopback|multicast} fe80::1/64 {1 16384 lo0  up|loopback|multicast}]`,
+			output: `[::1 {1 16384 lo0  up|loopback|multicast} fe80::1%lo0/64 {1 16384 lo0  up|loopback|multicast}]`,
 		},
 		{
 			name:   "better example for IP types",
 			input:  `{{. | include "type" "IPv4|IPv6" | include "name" "^lo0$" | sort "type" | sort "address" }}`,
-			output: `[127.0.0.1/8 {1 16384 lo0  up|loopback|multicast} ::1 {1 16384 lo0  up|loopback|multicast} fe80::1/64 {1 16384 lo0  up|loopback|multicast}]`,
+			output: `[127.0.0.1/8 {1 16384 lo0  up|loopback|multicast} ::1 {1 16384 lo0  up|loopback|multicast} fe80::1%lo0/64 {1 16384 lo0  up|loopback|multicast}]`,
 		},
 		{
 			name:   "ifAddrs1",
@@ -63,17 +65,17 @@ func TestSockAddr_Parse(t *testing.T) {
 		{
 			name:   "ifAddrs2",
 			input:  `{{. | include "type" "IP" | include "name" "^lo0$" | sort "type" | sort "address" }}`,
-			output: `[127.0.0.1/8 {1 16384 lo0  up|loopback|multicast} ::1 {1 16384 lo0  up|loopback|multicast} fe80::1/64 {1 16384 lo0  up|loopback|multicast}]`,
+			output: `[127.0.0.1/8 {1 16384 lo0  up|loopback|multicast} ::1 {1 16384 lo0  up|loopback|multicast} fe80::1%lo0/64 {1 16384 lo0  up|loopback|multicast}]`,
 		},
 		{
 			name:   `range "dot" example`,
 			input:  `{{range . | include "type" "IP" | include "name" "^lo0$"}}{{.Name}} {{.SockAddr}} {{end}}`,
-			output: `lo0 127.0.0.1/8 lo0 ::1 lo0 fe80::1/64 `,
+			output: `lo0 127.0.0.1/8 lo0 ::1 lo0 fe80::1%lo0/64 `,
 		},
 		{
 			name:   `exclude "type"`,
 			input:  `{{. | exclude "type" "IPv4" | include "name" "^lo0$" | sort "address" | unique "name" | join "name" " "}} {{range . | exclude "type" "IPv4" | include "name" "^lo0$"}}{{.SockAddr}} {{end}}`,
-			output: `lo0 ::1 fe80::1/64 `,
+			output: `lo0 ::1 fe80::1%lo0/64 `,
 		},
 		{
 			name:   "with variable pipeline",
@@ -130,7 +132,7 @@ func TestSockAddr_Parse(t *testing.T) {
 		{
 			name:   `sort asc address old`,
 			input:  `{{with $ifSet := include "name" "lo0" . }}{{ range include "type" "IPv4" $ifSet | sort "address"}}{{ .SockAddr }} {{end}}{{ range include "type" "IPv6" $ifSet | sort "address"}}{{ .SockAddr }} {{end}}{{end}}`,
-			output: `127.0.0.1/8 ::1 fe80::1/64 `,
+			output: `127.0.0.1/8 ::1 fe80::1%lo0/64 `,
 		},
 		{
 			name:   `sort desc address`,
@@ -145,7 +147,7 @@ func TestSockAddr_Parse(t *testing.T) {
 		{
 			name:   `sort asc address`,
 			input:  `{{with $ifSet := include "name" "lo0" . }}{{ range include "type" "IPv6" $ifSet | sort "address"}}{{ .SockAddr }} {{end}}{{end}}`,
-			output: `::1 fe80::1/64 `,
+			output: `::1 fe80::1%lo0/64 `,
 		},
 		{
 			name:   "lo0 limit 1",
@@ -256,6 +258,18 @@ func TestSockAddr_Parse(t *testing.T) {
 			input:  `{{GetInterfaceIPs "en0"}}`,
 			output: `10.1.2.3 and 172.16.4.6`,
 		},
+		{
+			// Assume localhost resolves to 127.0.0.1 via the hosts file.
+			name:   "resolve",
+			input:  `{{resolve "localhost:8500" | include "type" "IPv4" | join "string" " "}}`,
+			output: `127.0.0.1:8500`,
+		},
+		{
+			name:   "resolve invalid hostname",
+			input:  `{{resolve "bad host:8500"}}`,
+			output: ``,
+			fail:   true,
+		},
 	}
 
 	for i, test := range tests {
@@ -276,3 +290,77 @@ func TestSockAddr_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestSockAddr_ParseErrors(t *testing.T) {
+	ifAddrs := sockaddr.IfAddrs{
+		sockaddr.IfAddr{SockAddr: sockaddr.MustIPv4Addr("10.0.0.1/24")},
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{
+			name:  "include selector",
+			input: `{{. | include "bogus" "x" }}`,
+			want:  sockaddr.ErrUnknownSelector,
+		},
+		{
+			name:  "exclude selector",
+			input: `{{. | exclude "bogus" "x" }}`,
+			want:  sockaddr.ErrUnknownSelector,
+		},
+		{
+			name:  "sort selector",
+			input: `{{. | sort "bogus" }}`,
+			want:  sockaddr.ErrUnknownSelector,
+		},
+		{
+			name:  "flag selector",
+			input: `{{. | include "flag" "bogus" }}`,
+			want:  sockaddr.ErrUnknownSelector,
+		},
+		{
+			name:  "rfc selector",
+			input: `{{. | include "rfc" "9999" }}`,
+			want:  sockaddr.ErrUnknownSelector,
+		},
+		{
+			name:  "attribute",
+			input: `{{. | attr "bogus" }}`,
+			want:  sockaddr.ErrUnknownAttribute,
+		},
+		{
+			name:  "mask size",
+			input: `{{. | include "size" "33" }}`,
+			want:  sockaddr.ErrInvalidMask,
+		},
+		{
+			name:  "network mask",
+			input: `{{. | include "network" "10.0.0.0/33" }}`,
+			want:  sockaddr.ErrInvalidMask,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := socktmpl.ParseIfAddrs(test.input, ifAddrs)
+			if err == nil {
+				t.Fatalf("expected %q to fail", test.input)
+			}
+			if !errors.Is(err, test.want) {
+				t.Fatalf("expected %v to wrap %v", err, test.want)
+			}
+		})
+	}
+
+	_, err := socktmpl.ParseIfAddrs(`{{. | include "network" "10.0.0.0/33" }}`, ifAddrs)
+	var pe *sockaddr.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected a ParseError: %v", err)
+	}
+	if pe.Input != "10.0.0.0/33" || pe.Kind != sockaddr.KindMask {
+		t.Fatalf("wrong ParseError: %#v", pe)
+	}
+}
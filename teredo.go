@@ -0,0 +1,61 @@
+package sockaddr
+
+import (
+	"fmt"
+)
+
+// teredoPrefix is the Teredo service prefix, 2001::/32 (RFC 4380).
+var teredoPrefix = MustIPv6Addr("2001::/32")
+
+// teredoFlagCone is the Cone bit of the flags of a Teredo address (RFC 4380,
+// §4).
+const teredoFlagCone = 0x8000
+
+// TeredoInfo holds the fields encoded in a Teredo address (RFC 4380, §4).
+type TeredoInfo struct {
+	// Server is the IPv4 address of the client's Teredo server.
+	Server IPv4Addr
+
+	// Client is the public IPv4 address of the client's NAT mapping, with
+	// the obfuscation removed.
+	Client IPv4Addr
+
+	// Port is the public UDP port of the client's NAT mapping, with the
+	// obfuscation removed.
+	Port IPPort
+
+	// Flags holds the 16 flag bits of the address.
+	Flags uint16
+}
+
+// Cone returns true if the Cone bit of the Teredo flags is set, i.e. the
+// client was behind a cone NAT when it qualified.
+func (t TeredoInfo) Cone() bool {
+	return t.Flags&teredoFlagCone != 0
+}
+
+// IsTeredo returns true if the IPv6Addr's address is within the Teredo
+// service prefix `2001::/32`.
+func (ipv6 IPv6Addr) IsTeredo() bool {
+	return teredoPrefix.ContainsAddress(ipv6.Address)
+}
+
+// Teredo decodes the fields of a Teredo address.  For example,
+// `2001:0:4136:e378:8000:63bf:3fff:fdd2` is the address of a client behind a
+// cone NAT mapped to `192.0.2.45:40000`, using the server `65.54.227.120`.  An
+// error is returned if the IPv6Addr is not a Teredo address, see IsTeredo().
+func (ipv6 IPv6Addr) Teredo() (TeredoInfo, error) {
+	if !ipv6.IsTeredo() {
+		return TeredoInfo{}, fmt.Errorf("Unable to decode %s: not a Teredo address", ipv6.zonedIPString())
+	}
+
+	b := Uint128(ipv6.Address).Bytes()
+	server := uint32(b[4])<<24 | uint32(b[5])<<16 | uint32(b[6])<<8 | uint32(b[7])
+	client := uint32(b[12])<<24 | uint32(b[13])<<16 | uint32(b[14])<<8 | uint32(b[15])
+	return TeredoInfo{
+		Server: IPv4Addr{Address: IPv4Address(server), Mask: IPv4HostMask},
+		Client: IPv4Addr{Address: IPv4Address(^client), Mask: IPv4HostMask},
+		Port:   IPPort(^(uint16(b[10])<<8 | uint16(b[11]))),
+		Flags:  uint16(b[8])<<8 | uint16(b[9]),
+	}, nil
+}
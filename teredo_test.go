@@ -0,0 +1,98 @@
+package sockaddr_test
+
+import (
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+func TestIPv6Addr_Teredo(t *testing.T) {
+	tests := []struct {
+		input  string
+		server string
+		client string
+		port   sockaddr.IPPort
+		flags  uint16
+		cone   bool
+		fail   bool
+	}{
+		{
+			input:  "2001:0:4136:e378:8000:63bf:3fff:fdd2",
+			server: "65.54.227.120",
+			client: "192.0.2.45",
+			port:   40000,
+			flags:  0x8000,
+			cone:   true,
+		},
+		{
+			input:  "2001:0:c000:201:0:f227:f5ff:fffe",
+			server: "192.0.2.1",
+			client: "10.0.0.1",
+			port:   3544,
+			flags:  0,
+		},
+		{
+			input:  "[2001:0:c000:201:4136:ffff:ffff:ffff]:80",
+			server: "192.0.2.1",
+			client: "0.0.0.0",
+			port:   0,
+			flags:  0x4136,
+		},
+		{input: "2001:db8::1", fail: true},
+		{input: "2002:c000:221::1", fail: true},
+	}
+
+	for _, test := range tests {
+		ipv6 := sockaddr.MustIPv6Addr(test.input)
+		if ipv6.IsTeredo() == test.fail {
+			t.Errorf("expected IsTeredo() of %s to be %t", test.input, !test.fail)
+		}
+
+		teredo, err := ipv6.Teredo()
+		if test.fail {
+			if err == nil {
+				t.Errorf("expected Teredo() of %s to fail, received %+v", test.input, teredo)
+			}
+			if attr := sockaddr.IPv6AddrAttr(ipv6, "teredo_server"); attr != "" {
+				t.Errorf("expected empty teredo_server for %s, received %q", test.input, attr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Teredo() of %s: %v", test.input, err)
+			continue
+		}
+
+		if teredo.Server.String() != test.server {
+			t.Errorf("Teredo() server of %s: expected %q, received %q", test.input, test.server, teredo.Server)
+		}
+		if teredo.Client.String() != test.client {
+			t.Errorf("Teredo() client of %s: expected %q, received %q", test.input, test.client, teredo.Client)
+		}
+		if teredo.Port != test.port {
+			t.Errorf("Teredo() port of %s: expected %d, received %d", test.input, test.port, teredo.Port)
+		}
+		if teredo.Flags != test.flags {
+			t.Errorf("Teredo() flags of %s: expected %#04x, received %#04x", test.input, test.flags, teredo.Flags)
+		}
+		if teredo.Cone() != test.cone {
+			t.Errorf("expected Cone() of %s to be %t", test.input, test.cone)
+		}
+	}
+}
+
+func TestIPv6AddrAttr_Teredo(t *testing.T) {
+	ipv6 := sockaddr.MustIPv6Addr("2001:0:4136:e378:8000:63bf:3fff:fdd2")
+	expected := map[sockaddr.AttrName]string{
+		"teredo_server": "65.54.227.120",
+		"teredo_client": "192.0.2.45",
+		"teredo_port":   "40000",
+		"teredo_flags":  "0x8000",
+	}
+
+	for attr, output := range expected {
+		if got := sockaddr.IPv6AddrAttr(ipv6, attr); got != output {
+			t.Errorf("%s of %s: expected %q, received %q", attr, ipv6, output, got)
+		}
+	}
+}
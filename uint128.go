@@ -0,0 +1,202 @@
+package sockaddr
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// Uint128 is a fixed-size, unsigned 128-bit integer used to represent IPv6
+// addresses, networks, and masks.  Unlike a *big.Int, a Uint128 is a value
+// type: it is comparable with ==, safe to copy, and none of its arithmetic or
+// bitwise operations allocate.  All arithmetic wraps modulo 2^128.
+type Uint128 struct {
+	// Hi contains the 64 most significant bits.
+	Hi uint64
+
+	// Lo contains the 64 least significant bits.
+	Lo uint64
+}
+
+// Uint128FromBigInt converts a *big.Int to a Uint128.  Bits beyond the low
+// 128 bits of bi are discarded and negative values are converted using their
+// two's complement representation.
+func Uint128FromBigInt(bi *big.Int) Uint128 {
+	if bi == nil {
+		return Uint128{}
+	}
+
+	mag := new(big.Int).Abs(bi)
+	var buf [IPv6len]byte
+	b := mag.Bytes()
+	if len(b) > IPv6len {
+		b = b[len(b)-IPv6len:]
+	}
+	copy(buf[IPv6len-len(b):], b)
+
+	u := Uint128FromBytes(buf)
+	if bi.Sign() < 0 {
+		u = Uint128{}.Sub(u)
+	}
+	return u
+}
+
+// Uint128FromBytes converts a big endian, 16 byte array to a Uint128.
+func Uint128FromBytes(b [IPv6len]byte) Uint128 {
+	return Uint128{
+		Hi: binary.BigEndian.Uint64(b[:8]),
+		Lo: binary.BigEndian.Uint64(b[8:]),
+	}
+}
+
+// Uint128FromInt64 converts an int64 to a Uint128.  Negative values are sign
+// extended so that adding the result to a Uint128 is equivalent to
+// subtracting the absolute value.
+func Uint128FromInt64(i int64) Uint128 {
+	if i < 0 {
+		return Uint128{Hi: ^uint64(0), Lo: uint64(i)}
+	}
+	return Uint128{Lo: uint64(i)}
+}
+
+// uint128Mask returns a Uint128 with the prefixLen most significant bits set.
+func uint128Mask(prefixLen int) Uint128 {
+	switch {
+	case prefixLen <= 0:
+		return Uint128{}
+	case prefixLen >= 128:
+		return Uint128{Hi: ^uint64(0), Lo: ^uint64(0)}
+	default:
+		return Uint128{Hi: ^uint64(0), Lo: ^uint64(0)}.Lsh(uint(128 - prefixLen))
+	}
+}
+
+// Add returns u+v, wrapping on overflow.
+func (u Uint128) Add(v Uint128) Uint128 {
+	lo, carry := bits.Add64(u.Lo, v.Lo, 0)
+	hi, _ := bits.Add64(u.Hi, v.Hi, carry)
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// And returns u&v.
+func (u Uint128) And(v Uint128) Uint128 {
+	return Uint128{Hi: u.Hi & v.Hi, Lo: u.Lo & v.Lo}
+}
+
+// AndNot returns u&^v.
+func (u Uint128) AndNot(v Uint128) Uint128 {
+	return Uint128{Hi: u.Hi &^ v.Hi, Lo: u.Lo &^ v.Lo}
+}
+
+// BigInt returns u as a newly allocated *big.Int.
+func (u Uint128) BigInt() *big.Int {
+	b := u.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+// Bytes returns u as a big endian, 16 byte array.
+func (u Uint128) Bytes() [IPv6len]byte {
+	var b [IPv6len]byte
+	binary.BigEndian.PutUint64(b[:8], u.Hi)
+	binary.BigEndian.PutUint64(b[8:], u.Lo)
+	return b
+}
+
+// Cmp compares u and v and returns -1 if u < v, 0 if u == v, or 1 if u > v.
+func (u Uint128) Cmp(v Uint128) int {
+	switch {
+	case u.Hi < v.Hi:
+		return -1
+	case u.Hi > v.Hi:
+		return 1
+	case u.Lo < v.Lo:
+		return -1
+	case u.Lo > v.Lo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsZero returns true if u is zero.
+func (u Uint128) IsZero() bool {
+	return u.Hi == 0 && u.Lo == 0
+}
+
+// LeadingZeros returns the number of leading zero bits in u.
+func (u Uint128) LeadingZeros() int {
+	if u.Hi != 0 {
+		return bits.LeadingZeros64(u.Hi)
+	}
+	return 64 + bits.LeadingZeros64(u.Lo)
+}
+
+// Lsh returns u<<n.
+func (u Uint128) Lsh(n uint) Uint128 {
+	switch {
+	case n >= 128:
+		return Uint128{}
+	case n >= 64:
+		return Uint128{Hi: u.Lo << (n - 64)}
+	default:
+		return Uint128{Hi: u.Hi<<n | u.Lo>>(64-n), Lo: u.Lo << n}
+	}
+}
+
+// Not returns ^u.
+func (u Uint128) Not() Uint128 {
+	return Uint128{Hi: ^u.Hi, Lo: ^u.Lo}
+}
+
+// OnesCount returns the number of one bits in u.
+func (u Uint128) OnesCount() int {
+	return bits.OnesCount64(u.Hi) + bits.OnesCount64(u.Lo)
+}
+
+// Or returns u|v.
+func (u Uint128) Or(v Uint128) Uint128 {
+	return Uint128{Hi: u.Hi | v.Hi, Lo: u.Lo | v.Lo}
+}
+
+// Rsh returns u>>n.
+func (u Uint128) Rsh(n uint) Uint128 {
+	switch {
+	case n >= 128:
+		return Uint128{}
+	case n >= 64:
+		return Uint128{Lo: u.Hi >> (n - 64)}
+	default:
+		return Uint128{Hi: u.Hi >> n, Lo: u.Lo>>n | u.Hi<<(64-n)}
+	}
+}
+
+// String returns the base 10 representation of u.
+func (u Uint128) String() string {
+	return u.Text(10)
+}
+
+// Sub returns u-v, wrapping on underflow.
+func (u Uint128) Sub(v Uint128) Uint128 {
+	lo, borrow := bits.Sub64(u.Lo, v.Lo, 0)
+	hi, _ := bits.Sub64(u.Hi, v.Hi, borrow)
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// Text returns the representation of u in the given base.  Base must be
+// between 2 and 62, inclusive.
+func (u Uint128) Text(base int) string {
+	return u.BigInt().Text(base)
+}
+
+// TrailingZeros returns the number of trailing zero bits in u.
+func (u Uint128) TrailingZeros() int {
+	if u.Lo != 0 {
+		return bits.TrailingZeros64(u.Lo)
+	}
+	return 64 + bits.TrailingZeros64(u.Hi)
+}
+
+// Xor returns u^v.
+func (u Uint128) Xor(v Uint128) Uint128 {
+	return Uint128{Hi: u.Hi ^ v.Hi, Lo: u.Lo ^ v.Lo}
+}
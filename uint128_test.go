@@ -0,0 +1,197 @@
+package sockaddr_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	sockaddr "github.com/hashicorp/go-sockaddr"
+)
+
+var uint128Max = sockaddr.Uint128{Hi: math.MaxUint64, Lo: math.MaxUint64}
+
+func TestUint128_Arithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  sockaddr.Uint128
+		want sockaddr.Uint128
+	}{
+		{
+			name: "add carry",
+			got:  sockaddr.Uint128{Lo: math.MaxUint64}.Add(sockaddr.Uint128{Lo: 1}),
+			want: sockaddr.Uint128{Hi: 1},
+		},
+		{
+			name: "add wraps",
+			got:  uint128Max.Add(sockaddr.Uint128{Lo: 1}),
+			want: sockaddr.Uint128{},
+		},
+		{
+			name: "sub borrow",
+			got:  sockaddr.Uint128{Hi: 1}.Sub(sockaddr.Uint128{Lo: 1}),
+			want: sockaddr.Uint128{Lo: math.MaxUint64},
+		},
+		{
+			name: "sub wraps",
+			got:  sockaddr.Uint128{}.Sub(sockaddr.Uint128{Lo: 1}),
+			want: uint128Max,
+		},
+		{
+			name: "add negative int64",
+			got:  sockaddr.Uint128{Hi: 1}.Add(sockaddr.Uint128FromInt64(-1)),
+			want: sockaddr.Uint128{Lo: math.MaxUint64},
+		},
+		{
+			name: "lsh across words",
+			got:  sockaddr.Uint128{Lo: 1 << 63}.Lsh(1),
+			want: sockaddr.Uint128{Hi: 1},
+		},
+		{
+			name: "lsh 64",
+			got:  sockaddr.Uint128{Lo: 3}.Lsh(64),
+			want: sockaddr.Uint128{Hi: 3},
+		},
+		{
+			name: "lsh 128",
+			got:  uint128Max.Lsh(128),
+			want: sockaddr.Uint128{},
+		},
+		{
+			name: "rsh across words",
+			got:  sockaddr.Uint128{Hi: 1}.Rsh(1),
+			want: sockaddr.Uint128{Lo: 1 << 63},
+		},
+		{
+			name: "rsh 72",
+			got:  sockaddr.Uint128{Hi: 0xff00}.Rsh(72),
+			want: sockaddr.Uint128{Lo: 0xff},
+		},
+		{
+			name: "and not",
+			got:  uint128Max.AndNot(sockaddr.Uint128{Hi: math.MaxUint64}),
+			want: sockaddr.Uint128{Lo: math.MaxUint64},
+		},
+		{
+			name: "xor",
+			got:  uint128Max.Xor(sockaddr.Uint128{Lo: math.MaxUint64}),
+			want: sockaddr.Uint128{Hi: math.MaxUint64},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.got != test.want {
+				t.Errorf("expected %#v, received %#v", test.want, test.got)
+			}
+		})
+	}
+}
+
+func TestUint128_Cmp(t *testing.T) {
+	tests := []struct {
+		a, b sockaddr.Uint128
+		want int
+	}{
+		{a: sockaddr.Uint128{}, b: sockaddr.Uint128{}, want: 0},
+		{a: sockaddr.Uint128{Lo: 1}, b: sockaddr.Uint128{Lo: 2}, want: -1},
+		{a: sockaddr.Uint128{Hi: 1}, b: sockaddr.Uint128{Lo: math.MaxUint64}, want: 1},
+		{a: sockaddr.Uint128{Hi: 1, Lo: 1}, b: sockaddr.Uint128{Hi: 2}, want: -1},
+	}
+
+	for i, test := range tests {
+		if got := test.a.Cmp(test.b); got != test.want {
+			t.Errorf("[%d] expected %d, received %d", i, test.want, got)
+		}
+	}
+}
+
+func TestUint128_BigInt(t *testing.T) {
+	tests := []struct {
+		input string
+		want  sockaddr.Uint128
+	}{
+		{input: "0", want: sockaddr.Uint128{}},
+		{input: "18446744073709551616", want: sockaddr.Uint128{Hi: 1}},
+		{input: "340282366920938463463374607431768211455", want: uint128Max},
+		// Bits beyond 128 are discarded.
+		{input: "340282366920938463463374607431768211457", want: sockaddr.Uint128{Lo: 1}},
+		// Negative values use their two's complement representation.
+		{input: "-1", want: uint128Max},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			bi, ok := new(big.Int).SetString(test.input, 10)
+			if !ok {
+				t.Fatalf("unable to parse %q", test.input)
+			}
+
+			u := sockaddr.Uint128FromBigInt(bi)
+			if u != test.want {
+				t.Fatalf("expected %#v, received %#v", test.want, u)
+			}
+
+			if bi.Sign() >= 0 && bi.BitLen() <= 128 {
+				if u.String() != test.input {
+					t.Errorf("expected %q, received %q", test.input, u.String())
+				}
+				if u.BigInt().Cmp(bi) != 0 {
+					t.Errorf("failed round-trip: %s vs %s", u.BigInt(), bi)
+				}
+			}
+		})
+	}
+}
+
+func TestUint128_Bits(t *testing.T) {
+	tests := []struct {
+		input         sockaddr.Uint128
+		leadingZeros  int
+		trailingZeros int
+		onesCount     int
+	}{
+		{input: sockaddr.Uint128{}, leadingZeros: 128, trailingZeros: 128, onesCount: 0},
+		{input: uint128Max, leadingZeros: 0, trailingZeros: 0, onesCount: 128},
+		{input: sockaddr.Uint128{Lo: 1}, leadingZeros: 127, trailingZeros: 0, onesCount: 1},
+		{input: sockaddr.Uint128{Hi: 1 << 63}, leadingZeros: 0, trailingZeros: 127, onesCount: 1},
+		{input: sockaddr.Uint128{Hi: math.MaxUint64}, leadingZeros: 0, trailingZeros: 64, onesCount: 64},
+	}
+
+	for i, test := range tests {
+		if got := test.input.LeadingZeros(); got != test.leadingZeros {
+			t.Errorf("[%d] LeadingZeros: expected %d, received %d", i, test.leadingZeros, got)
+		}
+		if got := test.input.TrailingZeros(); got != test.trailingZeros {
+			t.Errorf("[%d] TrailingZeros: expected %d, received %d", i, test.trailingZeros, got)
+		}
+		if got := test.input.OnesCount(); got != test.onesCount {
+			t.Errorf("[%d] OnesCount: expected %d, received %d", i, test.onesCount, got)
+		}
+		if got := test.input.IsZero(); got != (test.onesCount == 0) {
+			t.Errorf("[%d] IsZero: received %t", i, got)
+		}
+	}
+}
+
+func TestIPv6_DeprecatedBigInt(t *testing.T) {
+	ipv6 := sockaddr.MustIPv6Addr("2001:db8::1/32")
+
+	bi := ipv6.Address.BigInt()
+	if got := sockaddr.IPv6AddressFromBigInt(bi); got != ipv6.Address {
+		t.Errorf("address failed round-trip: %v vs %v", got, ipv6.Address)
+	}
+
+	bi = ipv6.Mask.BigInt()
+	if got := sockaddr.IPv6MaskFromBigInt(bi); got != ipv6.Mask {
+		t.Errorf("mask failed round-trip: %v vs %v", got, ipv6.Mask)
+	}
+
+	network := ipv6.NetworkAddress()
+	bi = network.BigInt()
+	if got := sockaddr.IPv6NetworkFromBigInt(bi); got != network {
+		t.Errorf("network failed round-trip: %v vs %v", got, network)
+	}
+	if want := "42540766411282592856903984951653826560"; bi.String() != want {
+		t.Errorf("expected network %s, received %s", want, bi)
+	}
+}
@@ -1,10 +1,19 @@
 package sockaddr
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
+// unixSockStaleTimeout is the amount of time IsStale() waits for a connection
+// to a UNIX socket before deciding the socket is not stale.
+const unixSockStaleTimeout = 1 * time.Second
+
 type UnixSock struct {
 	SockAddr
 	path string
@@ -21,8 +30,30 @@ func init() {
 
 // NewUnixSock creates an UnixSock from a string path.  String can be in the
 // form of either URI-based string (e.g. `file:///etc/passwd`), an absolute
-// path (e.g. `/etc/passwd`), or a relative path (e.g. `./foo`).
+// path (e.g. `/etc/passwd`), a relative path (e.g. `./foo`), or the name of a
+// socket in the Linux abstract namespace prefixed with either `@` or a NUL
+// byte (e.g. `@myapp.sock`).  Abstract socket names are normalized to use the
+// `@` prefix, which is the form understood by Go's net package.
+//
+// The path must fit in the sun_path member of struct sockaddr_un (108 bytes on
+// Linux, 104 bytes on BSD-derived systems), including the trailing NUL byte
+// of file system paths.
 func NewUnixSock(s string) (ret UnixSock, err error) {
+	if len(s) > 0 && (s[0] == '@' || s[0] == '\x00') {
+		if len(s) == 1 {
+			return UnixSock{}, &ParseError{Input: s, Kind: KindPath, Family: TypeUnix, Cause: errors.New("empty abstract socket name")}
+		}
+		s = "@" + s[1:]
+
+		// The leading `@` is replaced by a NUL byte and abstract names are not
+		// NUL terminated.
+		if len(s) > maxUnixSockPathLen {
+			return UnixSock{}, &ParseError{Input: s, Kind: KindPath, Family: TypeUnix, Cause: fmt.Errorf("abstract socket name is %d bytes, the limit is %d bytes", len(s), maxUnixSockPathLen)}
+		}
+	} else if len(s) >= maxUnixSockPathLen {
+		return UnixSock{}, &ParseError{Input: s, Kind: KindPath, Family: TypeUnix, Cause: fmt.Errorf("path is %d bytes, the limit is %d bytes", len(s), maxUnixSockPathLen-1)}
+	}
+
 	ret.path = s
 	return ret, nil
 }
@@ -66,6 +97,12 @@ func (us UnixSock) DialStreamArgs() (network, dialArgs string) {
 	return "unix", us.path
 }
 
+// DialSeqPacketArgs returns the arguments required to be passed to
+// net.DialUnix() with the `unixpacket` (i.e. SOCK_SEQPACKET) network type.
+func (us UnixSock) DialSeqPacketArgs() (network, dialArgs string) {
+	return "unixpacket", us.path
+}
+
 // Equal returns true if a SockAddr is equal to the receiving UnixSock.
 func (us UnixSock) Equal(sa SockAddr) bool {
 	usb, ok := sa.(UnixSock)
@@ -86,12 +123,88 @@ func (us UnixSock) ListenPacketArgs() (network, dialArgs string) {
 	return "unixgram", us.path
 }
 
+// ListenSeqPacketArgs returns the arguments required to be passed to
+// net.ListenUnix() with the `unixpacket` (i.e. SOCK_SEQPACKET) network type.
+func (us UnixSock) ListenSeqPacketArgs() (network, dialArgs string) {
+	return "unixpacket", us.path
+}
+
 // ListenStreamArgs returns the arguments required to be passed to
 // net.ListenUnix() with the `unix` network type.
 func (us UnixSock) ListenStreamArgs() (network, dialArgs string) {
 	return "unix", us.path
 }
 
+// Exists returns true if the UnixSock's path exists on the file system.
+// Abstract sockets never exist on the file system.
+func (us UnixSock) Exists() bool {
+	_, err := us.stat()
+	return err == nil
+}
+
+// IsAbstract returns true if the UnixSock is a socket in the Linux abstract
+// namespace (e.g. `@myapp.sock`) and not a file system path.
+func (us UnixSock) IsAbstract() bool {
+	return len(us.path) > 0 && us.path[0] == '@'
+}
+
+// IsSocket returns true if the UnixSock's path exists on the file system and
+// is a socket.
+func (us UnixSock) IsSocket() bool {
+	fi, err := us.stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeSocket != 0
+}
+
+// IsStale returns true if the UnixSock's path is a socket on the file system
+// but connecting to it is refused, i.e. the process that created the socket
+// has gone away without removing it.  A stale socket can be safely removed
+// before the path is passed to net.Listen().
+func (us UnixSock) IsStale() bool {
+	if !us.IsSocket() {
+		return false
+	}
+
+	conn, err := net.DialTimeout("unix", us.path, unixSockStaleTimeout)
+	if err == nil {
+		conn.Close()
+		return false
+	}
+
+	return isConnRefused(err)
+}
+
+// FindStaleUnixSocks returns the stale UNIX sockets in dir.  See
+// UnixSock.IsStale() for details.
+func FindStaleUnixSocks(dir string) (UnixSocks, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to find stale UNIX sockets in %+q: %v", dir, err)
+	}
+
+	stale := make(UnixSocks, 0)
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSocket == 0 {
+			continue
+		}
+
+		us, err := NewUnixSock(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			// Paths that exceed the sun_path limit can not be connected to
+			continue
+		}
+
+		if us.IsStale() {
+			stale = append(stale, &us)
+		}
+	}
+
+	return stale, nil
+}
+
 // MustUnixSock is a helper method that must return an UnixSock or panic on
 // invalid input.
 func MustUnixSock(addr string) UnixSock {
@@ -102,7 +215,8 @@ func MustUnixSock(addr string) UnixSock {
 	return us
 }
 
-// Path returns the given path of the UnixSock
+// Path returns the given path of the UnixSock.  Abstract socket names are
+// returned with their `@` prefix.
 func (us UnixSock) Path() string {
 	return us.path
 }
@@ -112,6 +226,16 @@ func (us UnixSock) String() string {
 	return fmt.Sprintf("%+q", us.path)
 }
 
+// stat returns the os.FileInfo of the UnixSock's path.  Abstract sockets are
+// not present on the file system and always return an error.
+func (us UnixSock) stat() (os.FileInfo, error) {
+	if us.IsAbstract() {
+		return nil, fmt.Errorf("%+q is an abstract socket", us.path)
+	}
+
+	return os.Stat(us.path)
+}
+
 // Type is used as a type switch and returns TypeUnix
 func (UnixSock) Type() SockAddrType {
 	return TypeUnix
@@ -138,9 +262,57 @@ func unixAttrInit() {
 	// Sorted for human readability
 	unixAttrs = []AttrName{
 		"path",
+		"abstract",
+		"dir",
+		"basename",
+		"exists",
+		"is_socket",
+		"mode",
+		"owner",
 	}
 
 	unixAttrMap = map[AttrName]func(us UnixSock) string{
+		"abstract": func(us UnixSock) string {
+			return fmt.Sprintf("%t", us.IsAbstract())
+		},
+		"basename": func(us UnixSock) string {
+			if us.IsAbstract() {
+				return ""
+			}
+			return filepath.Base(us.path)
+		},
+		"dir": func(us UnixSock) string {
+			if us.IsAbstract() {
+				return ""
+			}
+			return filepath.Dir(us.path)
+		},
+		"exists": func(us UnixSock) string {
+			if us.IsAbstract() {
+				return ""
+			}
+			return fmt.Sprintf("%t", us.Exists())
+		},
+		"is_socket": func(us UnixSock) string {
+			if us.IsAbstract() {
+				return ""
+			}
+			return fmt.Sprintf("%t", us.IsSocket())
+		},
+		"mode": func(us UnixSock) string {
+			fi, err := us.stat()
+			if err != nil {
+				return ""
+			}
+			return fmt.Sprintf("%04o", fi.Mode().Perm())
+		},
+		"owner": func(us UnixSock) string {
+			fi, err := us.stat()
+			if err != nil {
+				return ""
+			}
+			return fileOwner(fi)
+		},
 		"path": func(us UnixSock) string {
 			return us.Path()
 		},
@@ -0,0 +1,17 @@
+// +build plan9 windows
+
+package sockaddr
+
+import "os"
+
+// fileOwner returns an empty string because file ownership is not expressed
+// as a numeric user ID on this platform.
+func fileOwner(fi os.FileInfo) string {
+	return ""
+}
+
+// isConnRefused returns false because stale sockets can not be detected on
+// this platform.
+func isConnRefused(err error) bool {
+	return false
+}
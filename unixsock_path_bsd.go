@@ -0,0 +1,7 @@
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package sockaddr
+
+// maxUnixSockPathLen is the size of the sun_path member of struct sockaddr_un
+// on BSD-derived systems.
+const maxUnixSockPathLen = 104
@@ -1,6 +1,12 @@
 package sockaddr_test
 
 import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	sockaddr "github.com/hashicorp/go-sockaddr"
@@ -71,6 +77,18 @@ func TestUnixSock_Equal(t *testing.T) {
 			sa:    sockaddr.MustUnixSock("/tmp/bar"),
 			equal: false,
 		},
+		{
+			name:  "abstract equal",
+			input: sockaddr.MustUnixSock("@foo"),
+			sa:    sockaddr.MustUnixSock("\x00foo"),
+			equal: true,
+		},
+		{
+			name:  "abstract not equal",
+			input: sockaddr.MustUnixSock("@foo"),
+			sa:    sockaddr.MustUnixSock("./@foo"),
+			equal: false,
+		},
 		{
 			name:  "ipv4",
 			input: sockaddr.MustUnixSock("/tmp/foo"),
@@ -100,9 +118,234 @@ func TestUnixSock_Equal(t *testing.T) {
 }
 
 func TestUnixSockAttrs(t *testing.T) {
-	const expectedNumAttrs = 1
+	const expectedNumAttrs = 8
 	usa := sockaddr.UnixSockAttrs()
 	if len(usa) != expectedNumAttrs {
 		t.Fatalf("wrong number of UnixSockAttrs: %d vs %d", len(usa), expectedNumAttrs)
 	}
 }
+
+func TestUnixSock_Abstract(t *testing.T) {
+	tests := []struct {
+		input    string
+		path     string
+		abstract bool
+		fail     bool
+	}{
+		{input: "@myapp.sock", path: "@myapp.sock", abstract: true},
+		{input: "\x00myapp.sock", path: "@myapp.sock", abstract: true},
+		{input: "/tmp/myapp.sock", path: "/tmp/myapp.sock"},
+		{input: "./@myapp.sock", path: "./@myapp.sock"},
+		{input: "@", fail: true},
+		{input: "\x00", fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			us, err := sockaddr.NewUnixSock(test.input)
+			if test.fail {
+				if err == nil {
+					t.Fatalf("expected %q to fail", test.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to create a UnixSock from %q: %v", test.input, err)
+			}
+
+			if us.Path() != test.path {
+				t.Errorf("wrong path: %q vs %q", us.Path(), test.path)
+			}
+			if us.IsAbstract() != test.abstract {
+				t.Errorf("wrong IsAbstract: %t", us.IsAbstract())
+			}
+
+			want := "false"
+			if test.abstract {
+				want = "true"
+			}
+			if got, _ := sockaddr.Attr(us, "abstract"); got != want {
+				t.Errorf("wrong abstract attr: %q vs %q", got, want)
+			}
+
+			if network, args := us.DialSeqPacketArgs(); network != "unixpacket" || args != test.path {
+				t.Errorf("wrong DialSeqPacketArgs: %q %q", network, args)
+			}
+			if network, args := us.ListenSeqPacketArgs(); network != "unixpacket" || args != test.path {
+				t.Errorf("wrong ListenSeqPacketArgs: %q %q", network, args)
+			}
+			if network, args := us.ListenStreamArgs(); network != "unix" || args != test.path {
+				t.Errorf("wrong ListenStreamArgs: %q %q", network, args)
+			}
+		})
+	}
+}
+
+func TestNewUnixSock_PathLength(t *testing.T) {
+	// sun_path is 104 bytes on BSD-derived systems and 108 bytes elsewhere
+	limit := 108
+	switch runtime.GOOS {
+	case "darwin", "dragonfly", "freebsd", "netbsd", "openbsd":
+		limit = 104
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		fail  bool
+	}{
+		{
+			name:  "longest path",
+			input: "/" + strings.Repeat("a", limit-2),
+		},
+		{
+			name:  "path too long",
+			input: "/" + strings.Repeat("a", limit-1),
+			fail:  true,
+		},
+		{
+			name:  "longest abstract name",
+			input: "@" + strings.Repeat("a", limit-1),
+		},
+		{
+			name:  "abstract name too long",
+			input: "@" + strings.Repeat("a", limit),
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := sockaddr.NewUnixSock(test.input)
+			switch {
+			case test.fail && err == nil:
+				t.Fatalf("expected a %d byte path to fail", len(test.input))
+			case !test.fail && err != nil:
+				t.Fatalf("unable to create a UnixSock from a %d byte path: %v", len(test.input), err)
+			}
+
+			if _, err = sockaddr.NewSockAddr(test.input); test.fail && err == nil {
+				t.Fatalf("expected NewSockAddr to fail on a %d byte path", len(test.input))
+			}
+		})
+	}
+}
+
+func TestUnixSock_FileAttrs(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skipf("UNIX sockets are not supported on %s", runtime.GOOS)
+	}
+
+	dir := t.TempDir()
+
+	livePath := filepath.Join(dir, "live.sock")
+	live, err := net.Listen("unix", livePath)
+	if err != nil {
+		t.Fatalf("unable to listen on %q: %v", livePath, err)
+	}
+	defer live.Close()
+
+	stalePath := filepath.Join(dir, "stale.sock")
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: stalePath, Net: "unix"})
+	if err != nil {
+		t.Fatalf("unable to listen on %q: %v", stalePath, err)
+	}
+	stale.SetUnlinkOnClose(false)
+	stale.Close()
+
+	filePath := filepath.Join(dir, "file")
+	if err := os.WriteFile(filePath, nil, 0600); err != nil {
+		t.Fatalf("unable to create %q: %v", filePath, err)
+	}
+
+	uid := fmt.Sprintf("%d", os.Getuid())
+	tests := []struct {
+		path  string
+		stale bool
+		attrs map[sockaddr.AttrName]string
+	}{
+		{
+			path: livePath,
+			attrs: map[sockaddr.AttrName]string{
+				"dir":       dir,
+				"basename":  "live.sock",
+				"exists":    "true",
+				"is_socket": "true",
+				"owner":     uid,
+			},
+		},
+		{
+			path:  stalePath,
+			stale: true,
+			attrs: map[sockaddr.AttrName]string{
+				"basename":  "stale.sock",
+				"exists":    "true",
+				"is_socket": "true",
+				"owner":     uid,
+			},
+		},
+		{
+			path: filePath,
+			attrs: map[sockaddr.AttrName]string{
+				"basename":  "file",
+				"exists":    "true",
+				"is_socket": "false",
+				"mode":      "0600",
+				"owner":     uid,
+			},
+		},
+		{
+			path: filepath.Join(dir, "missing.sock"),
+			attrs: map[sockaddr.AttrName]string{
+				"dir":       dir,
+				"basename":  "missing.sock",
+				"exists":    "false",
+				"is_socket": "false",
+				"mode":      "",
+				"owner":     "",
+			},
+		},
+		{
+			path: "@abstract.sock",
+			attrs: map[sockaddr.AttrName]string{
+				"dir":       "",
+				"basename":  "",
+				"exists":    "",
+				"is_socket": "",
+				"mode":      "",
+				"owner":     "",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(filepath.Base(test.path), func(t *testing.T) {
+			us := sockaddr.MustUnixSock(test.path)
+			if us.IsStale() != test.stale {
+				t.Errorf("expected IsStale() to be %t", test.stale)
+			}
+
+			for attr, want := range test.attrs {
+				got, err := sockaddr.Attr(us, attr)
+				if err != nil {
+					t.Fatalf("unable to get attr %q: %v", attr, err)
+				}
+				if got != want {
+					t.Errorf("wrong %q: %q vs %q", attr, got, want)
+				}
+			}
+		})
+	}
+
+	found, err := sockaddr.FindStaleUnixSocks(dir)
+	if err != nil {
+		t.Fatalf("unable to find stale sockets: %v", err)
+	}
+	if len(found) != 1 || found[0].Path() != stalePath {
+		t.Fatalf("wrong stale sockets: %v", found)
+	}
+
+	if _, err := sockaddr.FindStaleUnixSocks(filepath.Join(dir, "missing")); err == nil {
+		t.Fatalf("expected a missing directory to fail")
+	}
+}
@@ -0,0 +1,25 @@
+// +build !plan9,!windows
+
+package sockaddr
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// fileOwner returns the numeric user ID of the owner of fi.
+func fileOwner(fi os.FileInfo) string {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+
+	return strconv.FormatUint(uint64(st.Uid), 10)
+}
+
+// isConnRefused returns true if err was caused by ECONNREFUSED.
+func isConnRefused(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED)
+}